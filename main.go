@@ -132,14 +132,21 @@ func main() {
 	// Register available Jira tools
 	tools.RegisterJiraIssueTool(mcpServer)
 	tools.RegisterJiraSearchTool(mcpServer)
+	tools.RegisterJiraAggregateTool(mcpServer)
+	tools.RegisterJiraJQLTool(mcpServer)
+	tools.RegisterJiraStatusTool(mcpServer)
 	tools.RegisterJiraTransitionTool(mcpServer)
+	tools.RegisterJiraBulkTransitionTool(mcpServer)
 	tools.RegisterJiraCommentTools(mcpServer)
-	tools.RegisterJiraSprintTool(mcpServer) // Disabled - returns empty registration
-	// Temporarily disabled during migration to andygrunwald/go-jira:
-	// tools.RegisterJiraStatusTool(mcpServer)
-	// tools.RegisterJiraWorklogTool(mcpServer)
-	// tools.RegisterJiraHistoryTool(mcpServer)
-	// tools.RegisterJiraRelationshipTool(mcpServer)
+	tools.RegisterJiraHistoryTool(mcpServer)
+	tools.RegisterJiraFlowMetricsTool(mcpServer)
+	tools.RegisterJiraWorklogTool(mcpServer)
+	tools.RegisterJiraTimesheetTool(mcpServer)
+	tools.RegisterJiraSprintTool(mcpServer)
+	tools.RegisterJiraSprintReportTool(mcpServer)
+	tools.RegisterJiraBoardTool(mcpServer)
+	tools.RegisterJiraRelationshipTool(mcpServer)
+	tools.RegisterJiraGraphTool(mcpServer)
 
 	if *httpPort != "" {
 		fmt.Println()
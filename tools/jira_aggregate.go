@@ -0,0 +1,324 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type AggregateIssuesInput struct {
+	JQL       string `json:"jql" validate:"required"`
+	GroupBy   string `json:"group_by" validate:"required"`
+	Sum       string `json:"sum,omitempty"`
+	MaxIssues int    `json:"max_issues,omitempty"`
+}
+
+// defaultAggregateMaxIssues caps the number of issues fetched for an aggregation
+const defaultAggregateMaxIssues = 5000
+
+// aggregateGroupFields maps the supported group_by values to the search fields they need
+var aggregateGroupFields = map[string]string{
+	"status":    "status",
+	"assignee":  "assignee",
+	"priority":  "priority",
+	"component": "components",
+	"label":     "labels",
+	"sprint":    "",
+}
+
+// aggregateSumFields maps the supported sum values to the search fields they need
+var aggregateSumFields = map[string]string{
+	"story_points":       "",
+	"original_estimate":  "timeoriginalestimate",
+	"remaining_estimate": "timeestimate",
+	"time_spent":         "timespent",
+}
+
+// aggregateGroup is one combination of group values with its issue count and sums
+type aggregateGroup struct {
+	Values []string
+	Count  int
+	Sums   []float64
+}
+
+func RegisterJiraAggregateTool(s *server.MCPServer) {
+	jiraAggregateIssuesTool := mcp.NewTool("aggregate_issues",
+		mcp.WithDescription("Count the issues matching a JQL query grouped by one or two fields, optionally summing story points or time estimates per group. Use this instead of search_issue for questions like 'how many open bugs per component'"),
+		mcp.WithString("jql", mcp.Required(), mcp.Description("JQL query selecting the issues to aggregate (e.g., 'project = KP AND issuetype = Bug AND statusCategory != Done')")),
+		mcp.WithString("group_by", mcp.Required(), mcp.Description("One or two comma-separated fields to group by: status, assignee, priority, component, label, sprint (e.g., 'component' or 'status,assignee')")),
+		mcp.WithString("sum", mcp.Description("Comma-separated values to total per group: story_points, original_estimate, remaining_estimate, time_spent")),
+		mcp.WithNumber("max_issues", mcp.Description(fmt.Sprintf("Maximum number of issues to aggregate (default: %d)", defaultAggregateMaxIssues))),
+	)
+	s.AddTool(jiraAggregateIssuesTool, mcp.NewTypedToolHandler(JiraAggregateIssuesHandler))
+}
+
+func JiraAggregateIssuesHandler(ctx context.Context, request mcp.CallToolRequest, input AggregateIssuesInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	groupBy, err := aggregateOptions(input.GroupBy, aggregateGroupFields, "group_by")
+	if err != nil {
+		return nil, err
+	}
+	if len(groupBy) == 0 || len(groupBy) > 2 {
+		return nil, fmt.Errorf("group_by takes one or two fields, got %d", len(groupBy))
+	}
+
+	sums, err := aggregateOptions(input.Sum, aggregateSumFields, "sum")
+	if err != nil {
+		return nil, err
+	}
+
+	maxIssues := input.MaxIssues
+	if maxIssues <= 0 {
+		maxIssues = defaultAggregateMaxIssues
+	}
+
+	// Only fetch the fields the aggregation reads
+	fields := []string{}
+	for _, group := range groupBy {
+		if field := aggregateGroupFields[group]; field != "" {
+			fields = append(fields, field)
+		}
+	}
+	for _, sum := range sums {
+		if field := aggregateSumFields[sum]; field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	sprintFieldID := ""
+	if containsFold(groupBy, "sprint") {
+		if sprintFieldID, err = util.DiscoverSprintFieldID(ctx, client); err != nil {
+			return nil, err
+		}
+		fields = append(fields, sprintFieldID)
+	}
+
+	storyPointsFieldID := ""
+	if containsFold(sums, "story_points") {
+		storyPoints, err := util.DiscoverStoryPointsField(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		storyPointsFieldID = storyPoints.ID
+		fields = append(fields, storyPointsFieldID)
+	}
+
+	// One issue past the limit tells whether the aggregation is complete
+	issues, err := searchAllIssues(ctx, client, input.JQL, &jira.SearchOptions{Fields: fields}, maxIssues+1)
+	if err != nil {
+		return nil, err
+	}
+	truncated := len(issues) > maxIssues
+	if truncated {
+		issues = issues[:maxIssues]
+	}
+
+	if len(issues) == 0 {
+		return mcp.NewToolResultText("No issues found matching the query."), nil
+	}
+
+	groups := make(map[string]*aggregateGroup)
+	totals := make([]float64, len(sums))
+	for index := range issues {
+		issue := &issues[index]
+
+		values := make([]float64, len(sums))
+		for sumIndex, sum := range sums {
+			values[sumIndex] = aggregateSumValue(issue, sum, storyPointsFieldID)
+			totals[sumIndex] += values[sumIndex]
+		}
+
+		// Multi-value fields such as labels count the issue once under each of its values
+		combinations := [][]string{{}}
+		for _, group := range groupBy {
+			var next [][]string
+			for _, combination := range combinations {
+				for _, value := range aggregateGroupValues(issue, group, sprintFieldID) {
+					next = append(next, append(append([]string{}, combination...), value))
+				}
+			}
+			combinations = next
+		}
+
+		for _, combination := range combinations {
+			key := strings.Join(combination, "\x00")
+			entry, ok := groups[key]
+			if !ok {
+				entry = &aggregateGroup{Values: combination, Sums: make([]float64, len(sums))}
+				groups[key] = entry
+			}
+			entry.Count++
+			for sumIndex := range sums {
+				entry.Sums[sumIndex] += values[sumIndex]
+			}
+		}
+	}
+
+	sorted := make([]*aggregateGroup, 0, len(groups))
+	for _, entry := range groups {
+		sorted = append(sorted, entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return strings.Join(sorted[i].Values, "\x00") < strings.Join(sorted[j].Values, "\x00")
+	})
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Aggregated %d issues by %s\n", len(issues), strings.Join(groupBy, ", ")))
+	if truncated {
+		sb.WriteString(fmt.Sprintf("Note: only the first %d matching issues were aggregated; raise max_issues to include the rest\n", maxIssues))
+	}
+	for _, group := range groupBy {
+		if group == "component" || group == "label" || group == "sprint" {
+			sb.WriteString(fmt.Sprintf("Note: issues with several %ss are counted under each of them, so group counts can exceed the total\n", group))
+		}
+	}
+
+	sb.WriteString("\n|")
+	for _, group := range groupBy {
+		sb.WriteString(fmt.Sprintf(" %s |", aggregateLabel(group)))
+	}
+	sb.WriteString(" Issues |")
+	for _, sum := range sums {
+		sb.WriteString(fmt.Sprintf(" %s |", aggregateLabel(sum)))
+	}
+	sb.WriteString("\n|")
+	sb.WriteString(strings.Repeat("---|", len(groupBy)+1+len(sums)))
+	sb.WriteString("\n")
+
+	for _, entry := range sorted {
+		sb.WriteString("|")
+		for _, value := range entry.Values {
+			sb.WriteString(fmt.Sprintf(" %s |", strings.ReplaceAll(value, "|", "\\|")))
+		}
+		sb.WriteString(fmt.Sprintf(" %d |", entry.Count))
+		for sumIndex, sum := range sums {
+			sb.WriteString(fmt.Sprintf(" %s |", formatAggregateSum(sum, entry.Sums[sumIndex])))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("| **Total** |")
+	sb.WriteString(strings.Repeat(" |", len(groupBy)-1))
+	sb.WriteString(fmt.Sprintf(" **%d** |", len(issues)))
+	for sumIndex, sum := range sums {
+		sb.WriteString(fmt.Sprintf(" **%s** |", formatAggregateSum(sum, totals[sumIndex])))
+	}
+	sb.WriteString("\n")
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// aggregateOptions splits a comma-separated list and checks every entry against the supported options
+func aggregateOptions(value string, supported map[string]string, parameter string) ([]string, error) {
+	var options []string
+	for _, option := range strings.Split(value, ",") {
+		option = strings.ToLower(strings.TrimSpace(option))
+		if option == "" {
+			continue
+		}
+		if _, ok := supported[option]; !ok {
+			valid := make([]string, 0, len(supported))
+			for name := range supported {
+				valid = append(valid, name)
+			}
+			sort.Strings(valid)
+			return nil, fmt.Errorf("unsupported %s value %q. Valid values: %s", parameter, option, strings.Join(valid, ", "))
+		}
+		if !containsFold(options, option) {
+			options = append(options, option)
+		}
+	}
+	return options, nil
+}
+
+// aggregateGroupValues returns the values an issue is grouped under; an empty field yields a single "(none)" group
+func aggregateGroupValues(issue *jira.Issue, group string, sprintFieldID string) []string {
+	var values []string
+	if fields := issue.Fields; fields != nil {
+		switch group {
+		case "status":
+			if fields.Status != nil {
+				values = append(values, fields.Status.Name)
+			}
+		case "assignee":
+			if fields.Assignee == nil {
+				return []string{"Unassigned"}
+			}
+			values = append(values, fields.Assignee.DisplayName)
+		case "priority":
+			if fields.Priority != nil {
+				values = append(values, fields.Priority.Name)
+			}
+		case "component":
+			for _, component := range fields.Components {
+				values = append(values, component.Name)
+			}
+		case "label":
+			values = append(values, fields.Labels...)
+		case "sprint":
+			values = util.SprintNames(fields.Unknowns[sprintFieldID])
+		}
+	}
+
+	if len(values) == 0 {
+		return []string{"(none)"}
+	}
+	return values
+}
+
+// aggregateSumValue reads a summed value of an issue; time values are in seconds
+func aggregateSumValue(issue *jira.Issue, sum string, storyPointsFieldID string) float64 {
+	if issue.Fields == nil {
+		return 0
+	}
+
+	switch sum {
+	case "story_points":
+		switch value := issue.Fields.Unknowns[storyPointsFieldID].(type) {
+		case float64:
+			return value
+		case string:
+			number, _ := strconv.ParseFloat(value, 64)
+			return number
+		}
+	case "original_estimate":
+		return float64(issue.Fields.TimeOriginalEstimate)
+	case "remaining_estimate":
+		return float64(issue.Fields.TimeEstimate)
+	case "time_spent":
+		return float64(issue.Fields.TimeSpent)
+	}
+	return 0
+}
+
+func formatAggregateSum(sum string, value float64) string {
+	if sum == "story_points" {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	if value == 0 {
+		return "0"
+	}
+	return util.FormatDuration(int(value))
+}
+
+// aggregateLabel turns an option such as "story_points" into a column heading ("Story Points")
+func aggregateLabel(option string) string {
+	words := strings.Split(option, "_")
+	for index, word := range words {
+		words[index] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
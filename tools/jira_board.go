@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type ListBoardsInput struct {
+	ProjectKey string `json:"project_key,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+type GetBoardConfigurationInput struct {
+	BoardID    string `json:"board_id,omitempty"`
+	ProjectKey string `json:"project_key,omitempty"`
+}
+
+// boardPage is a single page of the board listing
+type boardPage struct {
+	MaxResults int          `json:"maxResults"`
+	StartAt    int          `json:"startAt"`
+	IsLast     bool         `json:"isLast"`
+	Values     []util.Board `json:"values"`
+}
+
+func RegisterJiraBoardTool(s *server.MCPServer) {
+	jiraListBoardsTool := mcp.NewTool("list_boards",
+		mcp.WithDescription("List Jira agile boards with their IDs, types, and projects. Use it to find the board that belongs to a project"),
+		mcp.WithString("project_key", mcp.Description("Only return boards relevant to this project (e.g., KP)")),
+		mcp.WithString("type", mcp.Description("Only return boards of this type: scrum or kanban")),
+		mcp.WithString("name", mcp.Description("Only return boards whose name contains this text")),
+	)
+	s.AddTool(jiraListBoardsTool, mcp.NewTypedToolHandler(JiraListBoardsHandler))
+
+	jiraGetBoardConfigurationTool := mcp.NewTool("get_board_configuration",
+		mcp.WithDescription("Retrieve the configuration of an agile board: column-to-status mappings, estimation field, ranking field, and board filter. Provide either board_id or project_key"),
+		mcp.WithString("board_id", mcp.Description("Numeric ID of the agile board (e.g., 42)")),
+		mcp.WithString("project_key", mcp.Description("Project key used to look up the board when board_id is not known (e.g., KP)")),
+	)
+	s.AddTool(jiraGetBoardConfigurationTool, mcp.NewTypedToolHandler(JiraGetBoardConfigurationHandler))
+}
+
+func JiraListBoardsHandler(ctx context.Context, request mcp.CallToolRequest, input ListBoardsInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	boardType := strings.ToLower(strings.TrimSpace(input.Type))
+	if boardType != "" && boardType != "scrum" && boardType != "kanban" {
+		return nil, fmt.Errorf("invalid type %q: must be scrum or kanban", input.Type)
+	}
+
+	boards, err := listBoards(ctx, client, strings.TrimSpace(input.ProjectKey), boardType, strings.TrimSpace(input.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(boards) == 0 {
+		return mcp.NewToolResultText("No boards found matching the criteria."), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d boards:\n\n", len(boards)))
+	for _, board := range boards {
+		sb.WriteString(util.FormatBoard(&board))
+		sb.WriteString("\n")
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func JiraGetBoardConfigurationHandler(ctx context.Context, request mcp.CallToolRequest, input GetBoardConfigurationInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	boardID, err := resolveBoardID(ctx, client, input.BoardID, input.ProjectKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := getBoardConfiguration(ctx, client, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Columns only reference status IDs, so resolve them to names
+	statusNames := make(map[string]string)
+	statuses, _, err := client.Status.GetAllStatusesWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses: %v", err)
+	}
+	for _, status := range statuses {
+		statusNames[status.ID] = status.Name
+	}
+
+	// The filter JQL is informative only; boards may use filters the user cannot see
+	filterJQL := ""
+	if filterID, err := strconv.Atoi(config.Filter.ID); err == nil {
+		if filter, _, err := client.Filter.GetWithContext(ctx, filterID); err == nil {
+			filterJQL = filter.Jql
+		}
+	}
+
+	return mcp.NewToolResultText(util.FormatBoardConfiguration(config, statusNames, filterJQL)), nil
+}
+
+// resolveBoardID returns the numeric board ID from an explicit board_id or, when only
+// a project key is given, looks up a board of that project of the given type ("" for any).
+// Boards located in the project are preferred over boards that merely reference it in their filter
+func resolveBoardID(ctx context.Context, client *jira.Client, boardID string, projectKey string, boardType string) (int, error) {
+	if boardID = strings.TrimSpace(boardID); boardID != "" {
+		id, err := strconv.Atoi(boardID)
+		if err != nil {
+			return 0, fmt.Errorf("invalid board_id %q: must be a number", boardID)
+		}
+		return id, nil
+	}
+
+	if projectKey = strings.TrimSpace(projectKey); projectKey == "" {
+		return 0, fmt.Errorf("either board_id or project_key is required")
+	}
+
+	boards, err := listBoards(ctx, client, projectKey, boardType, "")
+	if err != nil {
+		return 0, err
+	}
+
+	if len(boards) == 0 {
+		if boardType != "" {
+			return 0, fmt.Errorf("no %s board found for project %s; use list_boards to find a board ID", boardType, projectKey)
+		}
+		return 0, fmt.Errorf("no board found for project %s; use list_boards to find a board ID", projectKey)
+	}
+
+	sort.SliceStable(boards, func(i, j int) bool {
+		return boardInProject(boards[i], projectKey) && !boardInProject(boards[j], projectKey)
+	})
+
+	return boards[0].ID, nil
+}
+
+func boardInProject(board util.Board, projectKey string) bool {
+	return board.Location != nil && strings.EqualFold(board.Location.ProjectKey, projectKey)
+}
+
+// listBoards fetches every board matching the optional filters, following the Agile API pagination
+func listBoards(ctx context.Context, client *jira.Client, projectKey string, boardType string, name string) ([]util.Board, error) {
+	var boards []util.Board
+
+	startAt := 0
+	for {
+		query := url.Values{}
+		query.Set("startAt", strconv.Itoa(startAt))
+		if projectKey != "" {
+			query.Set("projectKeyOrId", projectKey)
+		}
+		if boardType != "" {
+			query.Set("type", boardType)
+		}
+		if name != "" {
+			query.Set("name", name)
+		}
+
+		req, err := client.NewRequestWithContext(ctx, "GET", "rest/agile/1.0/board?"+query.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+
+		var page boardPage
+		response, err := client.Do(req, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list boards: %v", jira.NewJiraError(response, err))
+		}
+
+		boards = append(boards, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return boards, nil
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// getBoardConfiguration fetches the configuration of a board from the Agile API
+func getBoardConfiguration(ctx context.Context, client *jira.Client, boardID int) (*util.BoardConfiguration, error) {
+	req, err := client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("rest/agile/1.0/board/%d/configuration", boardID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	config := new(util.BoardConfiguration)
+	response, err := client.Do(req, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board configuration: %v", jira.NewJiraError(response, err))
+	}
+
+	return config, nil
+}
@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type BulkTransitionInput struct {
+	JQL         string                 `json:"jql" validate:"required"`
+	Status      string                 `json:"status" validate:"required"`
+	Comment     string                 `json:"comment,omitempty"`
+	Limit       int                    `json:"limit,omitempty"`
+	Concurrency int                    `json:"concurrency,omitempty"`
+	Resolution  string                 `json:"resolution,omitempty"`
+	FixVersions string                 `json:"fix_versions,omitempty"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+}
+
+const (
+	// defaultBulkTransitionLimit caps how many matching issues are transitioned
+	defaultBulkTransitionLimit = 50
+	// maxBulkTransitionLimit is the hard upper bound for limit
+	maxBulkTransitionLimit = 500
+	// defaultBulkTransitionConcurrency is the number of issues transitioned in parallel
+	defaultBulkTransitionConcurrency = 5
+	// maxBulkTransitionConcurrency keeps parallel requests within Jira rate limits
+	maxBulkTransitionConcurrency = 10
+)
+
+// bulkTransitionResult is the outcome of transitioning one issue
+type bulkTransitionResult struct {
+	Key    string
+	From   string
+	To     string
+	Result string
+	Failed bool
+}
+
+func RegisterJiraBulkTransitionTool(s *server.MCPServer) {
+	jiraBulkTransitionTool := mcp.NewTool("bulk_transition",
+		mcp.WithDescription("Transition every issue matching a JQL query to a target status or through a named transition, in parallel. Returns a per-issue success or failure table"),
+		mcp.WithString("jql", mcp.Required(), mcp.Description("JQL query selecting the issues to transition (e.g., 'fixVersion = 1.4 AND status = \"Ready for QA\"')")),
+		mcp.WithString("status", mcp.Required(), mcp.Description("Target status name or transition name (e.g., 'Done', 'Close Issue')")),
+		mcp.WithString("comment", mcp.Description("Optional comment to add to each issue with the transition")),
+		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of issues to transition (default: %d, max: %d)", defaultBulkTransitionLimit, maxBulkTransitionLimit))),
+		mcp.WithNumber("concurrency", mcp.Description(fmt.Sprintf("Number of issues transitioned in parallel (default: %d, max: %d)", defaultBulkTransitionConcurrency, maxBulkTransitionConcurrency))),
+		mcp.WithString("resolution", mcp.Description("Resolution to set on the transition screen (e.g., 'Fixed', 'Done')")),
+		mcp.WithString("fix_versions", mcp.Description("Comma-separated fix versions to set on the transition screen")),
+		mcp.WithObject("fields", mcp.Description("Other transition screen fields by ID or name. Values are validated against each issue's transition screen")),
+	)
+	s.AddTool(jiraBulkTransitionTool, mcp.NewTypedToolHandler(JiraBulkTransitionHandler))
+}
+
+func JiraBulkTransitionHandler(ctx context.Context, request mcp.CallToolRequest, input BulkTransitionInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	values, err := transitionFieldValues(input.Fields, input.Resolution, "", input.FixVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultBulkTransitionLimit
+	}
+	if limit > maxBulkTransitionLimit {
+		limit = maxBulkTransitionLimit
+	}
+
+	concurrency := input.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkTransitionConcurrency
+	}
+	if concurrency > maxBulkTransitionConcurrency {
+		concurrency = maxBulkTransitionConcurrency
+	}
+
+	// One issue past the limit tells whether matching issues were left out
+	issues, err := searchAllIssues(ctx, client, input.JQL, &jira.SearchOptions{Fields: []string{"status"}}, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	truncated := len(issues) > limit
+	if truncated {
+		issues = issues[:limit]
+	}
+
+	if len(issues) == 0 {
+		return mcp.NewToolResultText("No issues found matching the query."), nil
+	}
+
+	target := strings.TrimSpace(input.Status)
+	results := make([]bulkTransitionResult, len(issues))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for index := range issues {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[index] = transitionOne(ctx, client, &issues[index], target, input.Comment, values)
+		}(index)
+	}
+	wg.Wait()
+
+	succeeded, skipped, failed := 0, 0, 0
+	var sb strings.Builder
+	sb.WriteString("| Issue | From | To | Result |\n|---|---|---|---|\n")
+	for _, result := range results {
+		switch {
+		case result.Failed:
+			failed++
+		case result.To == "":
+			skipped++
+		default:
+			succeeded++
+		}
+		to := result.To
+		if to == "" {
+			to = "-"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", result.Key, result.From, to, strings.ReplaceAll(result.Result, "\n", " ")))
+	}
+
+	header := fmt.Sprintf("Bulk transition to '%s': %d succeeded, %d skipped, %d failed of %d issues\n", target, succeeded, skipped, failed, len(issues))
+	if truncated {
+		header += fmt.Sprintf("Note: only the first %d matching issues were processed; raise limit or run again for the rest\n", limit)
+	}
+
+	return mcp.NewToolResultText(header + "\n" + sb.String()), nil
+}
+
+// transitionOne applies the target transition to a single issue, recording the outcome instead of failing
+func transitionOne(ctx context.Context, client *jira.Client, issue *jira.Issue, target string, comment string, values map[string]interface{}) bulkTransitionResult {
+	result := bulkTransitionResult{Key: issue.Key}
+	if issue.Fields != nil && issue.Fields.Status != nil {
+		result.From = issue.Fields.Status.Name
+	}
+
+	if strings.EqualFold(result.From, target) {
+		result.Result = "Skipped: already in status"
+		return result
+	}
+
+	transitions, _, err := client.Issue.GetTransitionsWithContext(ctx, issue.Key)
+	if err != nil {
+		result.Failed = true
+		result.Result = fmt.Sprintf("Failed to get transitions: %v", err)
+		return result
+	}
+
+	transition, err := resolveTransition(transitions, target)
+	if err != nil {
+		result.Failed = true
+		result.Result = fmt.Sprintf("Failed: %v", err)
+		return result
+	}
+
+	fields, screen, err := prepareTransitionFields(ctx, client, issue.Key, transition, values)
+	if err != nil {
+		result.Failed = true
+		result.Result = fmt.Sprintf("Failed: %v", err)
+		return result
+	}
+
+	if err := applyTransition(ctx, client, issue.Key, transition.ID, comment, fields); err != nil {
+		result.Failed = true
+		result.Result = fmt.Sprintf("Failed: %v", transitionFailure(ctx, client, issue.Key, transition, screen, fields, err))
+		return result
+	}
+
+	result.To = transition.To.Name
+	result.Result = fmt.Sprintf("Transitioned via '%s'", transition.Name)
+	return result
+}
@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// errUnknownIssueType is returned by getCreateFields when the issue type cannot be created in the project
+var errUnknownIssueType = errors.New("unknown issue type")
+
+// createMetaIssueType is an issue type that can be created in a project, with its create screen fields
+type createMetaIssueType struct {
+	ID      string               `json:"id"`
+	Name    string               `json:"name"`
+	Subtask bool                 `json:"subtask"`
+	Fields  map[string]fieldMeta `json:"fields,omitempty"`
+}
+
+// createMetaField is a create screen field in the paginated createmeta responses, which carry the ID inline
+type createMetaField struct {
+	FieldID string `json:"fieldId"`
+	fieldMeta
+}
+
+// createMetaPage covers the paginated createmeta endpoints of Jira Cloud (issueTypes / fields or results)
+// and Jira Data Center 9+ (values)
+type createMetaPage struct {
+	StartAt    int                   `json:"startAt"`
+	MaxResults int                   `json:"maxResults"`
+	Total      int                   `json:"total"`
+	IsLast     bool                  `json:"isLast"`
+	IssueTypes []createMetaIssueType `json:"issueTypes"`
+	Fields     []createMetaField     `json:"fields"`
+	Results    []createMetaField     `json:"results"`
+	Values     []json.RawMessage     `json:"values"`
+}
+
+// getCreateIssueTypes lists the issue types that can be created in a project.
+// It uses createmeta/{project}/issuetypes and falls back to the legacy createmeta endpoint
+func getCreateIssueTypes(ctx context.Context, client *jira.Client, projectKey string) ([]createMetaIssueType, error) {
+	var issueTypes []createMetaIssueType
+	endpoint := fmt.Sprintf("rest/api/2/issue/createmeta/%s/issuetypes", url.PathEscape(projectKey))
+	err := getCreateMetaPages(ctx, client, endpoint, func(page *createMetaPage) error {
+		issueTypes = append(issueTypes, page.IssueTypes...)
+		for _, raw := range page.Values {
+			var issueType createMetaIssueType
+			if err := json.Unmarshal(raw, &issueType); err != nil {
+				return err
+			}
+			issueTypes = append(issueTypes, issueType)
+		}
+		return nil
+	})
+	if err == nil {
+		return issueTypes, nil
+	}
+
+	// Instances before Jira 9 only offer the legacy endpoint
+	return getLegacyCreateMeta(ctx, client, projectKey, "")
+}
+
+// getCreateFields returns an issue type of a project together with its create screen fields.
+// An unknown issue type is an error that lists the valid ones
+func getCreateFields(ctx context.Context, client *jira.Client, projectKey string, issueTypeName string) (*createMetaIssueType, error) {
+	issueTypes, err := getCreateIssueTypes(ctx, client, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var issueType *createMetaIssueType
+	names := make([]string, 0, len(issueTypes))
+	for index := range issueTypes {
+		names = append(names, issueTypes[index].Name)
+		if strings.EqualFold(issueTypes[index].Name, issueTypeName) || issueTypes[index].ID == issueTypeName {
+			issueType = &issueTypes[index]
+		}
+	}
+	if issueType == nil {
+		return nil, fmt.Errorf("%w: %q cannot be created in project %s. Valid issue types: %s", errUnknownIssueType, issueTypeName, projectKey, strings.Join(names, ", "))
+	}
+
+	if issueType.Fields != nil {
+		return issueType, nil
+	}
+
+	fields := make(map[string]fieldMeta)
+	endpoint := fmt.Sprintf("rest/api/2/issue/createmeta/%s/issuetypes/%s", url.PathEscape(projectKey), url.PathEscape(issueType.ID))
+	err = getCreateMetaPages(ctx, client, endpoint, func(page *createMetaPage) error {
+		for _, field := range append(page.Fields, page.Results...) {
+			fields[field.FieldID] = field.fieldMeta
+		}
+		for _, raw := range page.Values {
+			var field createMetaField
+			if err := json.Unmarshal(raw, &field); err != nil {
+				return err
+			}
+			fields[field.FieldID] = field.fieldMeta
+		}
+		return nil
+	})
+	if err != nil {
+		legacy, legacyErr := getLegacyCreateMeta(ctx, client, projectKey, issueType.Name)
+		if legacyErr != nil {
+			return nil, legacyErr
+		}
+		for _, candidate := range legacy {
+			if candidate.ID == issueType.ID {
+				fields = candidate.Fields
+			}
+		}
+	}
+
+	issueType.Fields = fields
+	return issueType, nil
+}
+
+// getCreateMetaPages walks a paginated createmeta endpoint
+func getCreateMetaPages(ctx context.Context, client *jira.Client, endpoint string, handle func(page *createMetaPage) error) error {
+	startAt := 0
+	for {
+		req, err := client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s?startAt=%d&maxResults=100", endpoint, startAt), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %v", err)
+		}
+
+		var page createMetaPage
+		response, err := client.Do(req, &page)
+		if err != nil {
+			return fmt.Errorf("failed to get create metadata: %v", jira.NewJiraError(response, err))
+		}
+
+		if err := handle(&page); err != nil {
+			return fmt.Errorf("failed to decode create metadata: %v", err)
+		}
+
+		count := len(page.IssueTypes) + len(page.Fields) + len(page.Results) + len(page.Values)
+		startAt += count
+		if count == 0 || page.IsLast || (page.Total > 0 && startAt >= page.Total) || (page.Total == 0 && count < page.MaxResults) {
+			return nil
+		}
+	}
+}
+
+// getLegacyCreateMeta uses the createmeta endpoint that predates Jira 9 and was removed from Jira Cloud
+func getLegacyCreateMeta(ctx context.Context, client *jira.Client, projectKey string, issueTypeName string) ([]createMetaIssueType, error) {
+	query := url.Values{}
+	query.Set("projectKeys", projectKey)
+	if issueTypeName != "" {
+		query.Set("issuetypeNames", issueTypeName)
+		query.Set("expand", "projects.issuetypes.fields")
+	}
+
+	req, err := client.NewRequestWithContext(ctx, "GET", "rest/api/2/issue/createmeta?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	var result struct {
+		Projects []struct {
+			Key        string                `json:"key"`
+			IssueTypes []createMetaIssueType `json:"issuetypes"`
+		} `json:"projects"`
+	}
+	response, err := client.Do(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get create metadata: %v", jira.NewJiraError(response, err))
+	}
+
+	if len(result.Projects) == 0 {
+		return nil, fmt.Errorf("project %s not found or you do not have permission to create issues in it", projectKey)
+	}
+
+	return result.Projects[0].IssueTypes, nil
+}
@@ -0,0 +1,188 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+)
+
+// fieldMeta describes an editable field as returned by editmeta, createmeta and expand=transitions.fields
+type fieldMeta struct {
+	Required        bool             `json:"required"`
+	HasDefaultValue bool             `json:"hasDefaultValue"`
+	Name            string           `json:"name"`
+	Schema          jira.FieldSchema `json:"schema"`
+	Operations      []string         `json:"operations"`
+	AllowedValues   []allowedValue   `json:"allowedValues"`
+}
+
+// allowedValue is one option of a field restricted to a fixed set of values
+type allowedValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (v allowedValue) label() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return v.Value
+}
+
+func findFieldMeta(metas map[string]fieldMeta, name string) (string, *fieldMeta) {
+	if meta, ok := metas[name]; ok {
+		return name, &meta
+	}
+	for fieldID, meta := range metas {
+		if strings.EqualFold(fieldID, name) || strings.EqualFold(meta.Name, name) {
+			return fieldID, &meta
+		}
+	}
+	return "", nil
+}
+
+// fieldValue converts a plain value into the structure Jira expects for the field's schema.
+// Strings and lists of strings are converted; objects and other values are passed through unchanged
+func fieldValue(meta *fieldMeta, value interface{}) (interface{}, error) {
+	if list, ok := value.([]interface{}); ok && meta.Schema.Type == "array" {
+		items := make([]interface{}, 0, len(list))
+		for _, item := range list {
+			converted := item
+			if text, ok := item.(string); ok {
+				var err error
+				if converted, err = fieldItemValue(meta, meta.Schema.Items, strings.TrimSpace(text)); err != nil {
+					return nil, err
+				}
+			}
+			items = append(items, converted)
+		}
+		return items, nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	if meta.Schema.Type == "array" {
+		items := make([]interface{}, 0)
+		for _, item := range strings.Split(text, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			converted, err := fieldItemValue(meta, meta.Schema.Items, item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, converted)
+		}
+		return items, nil
+	}
+
+	return fieldItemValue(meta, meta.Schema.Type, text)
+}
+
+func fieldItemValue(meta *fieldMeta, itemType string, text string) (interface{}, error) {
+	if len(meta.AllowedValues) > 0 {
+		labels := make([]string, 0, len(meta.AllowedValues))
+		for _, allowed := range meta.AllowedValues {
+			if strings.EqualFold(allowed.label(), text) || allowed.ID == text {
+				return map[string]interface{}{"id": allowed.ID}, nil
+			}
+			labels = append(labels, allowed.label())
+		}
+		return nil, fmt.Errorf("invalid value %q for %s. Allowed values: %s", text, meta.Name, strings.Join(labels, ", "))
+	}
+
+	switch itemType {
+	case "user", "resolution", "priority", "version", "component":
+		return map[string]interface{}{"name": text}, nil
+	case "option":
+		return map[string]interface{}{"value": text}, nil
+	case "number":
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q for %s", text, meta.Name)
+		}
+		return number, nil
+	case "date":
+		date, err := util.ParseDateTime(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date for %s: %v", meta.Name, err)
+		}
+		return date.Format("2006-01-02"), nil
+	default:
+		return text, nil
+	}
+}
+
+// getEditMeta fetches the fields that can be edited on an issue, keyed by field ID
+func getEditMeta(ctx context.Context, client *jira.Client, issueKey string) (map[string]fieldMeta, error) {
+	req, err := client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("rest/api/2/issue/%s/editmeta", issueKey), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	var result struct {
+		Fields map[string]fieldMeta `json:"fields"`
+	}
+	response, err := client.Do(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edit metadata: %v", jira.NewJiraError(response, err))
+	}
+
+	return result.Fields, nil
+}
+
+func formatFieldMetas(metas map[string]fieldMeta) string {
+	if len(metas) == 0 {
+		return "(no fields)"
+	}
+
+	fieldIDs := make([]string, 0, len(metas))
+	for fieldID := range metas {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	sort.Strings(fieldIDs)
+
+	var sb strings.Builder
+	for _, fieldID := range fieldIDs {
+		meta := metas[fieldID]
+		sb.WriteString(fmt.Sprintf("- %s (%s)", meta.Name, fieldID))
+		if meta.Schema.Type == "array" {
+			sb.WriteString(fmt.Sprintf(" <array of %s>", meta.Schema.Items))
+		} else if meta.Schema.Type != "" {
+			sb.WriteString(fmt.Sprintf(" <%s>", meta.Schema.Type))
+		}
+		if meta.Required && meta.HasDefaultValue {
+			sb.WriteString(" [required, has default]")
+		} else if meta.Required {
+			sb.WriteString(" [required]")
+		}
+		if len(meta.AllowedValues) > 0 {
+			labels := make([]string, 0, len(meta.AllowedValues))
+			for _, allowed := range meta.AllowedValues {
+				labels = append(labels, allowed.label())
+			}
+			sb.WriteString(fmt.Sprintf(": %s", strings.Join(labels, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
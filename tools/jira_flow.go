@@ -0,0 +1,281 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type FlowMetricsInput struct {
+	JQL       string `json:"jql" validate:"required"`
+	MaxIssues int    `json:"max_issues,omitempty"`
+}
+
+// defaultFlowMaxIssues caps the number of issues whose changelogs are replayed
+const defaultFlowMaxIssues = 200
+
+// flowPercentiles are the percentiles reported for lead and cycle time
+var flowPercentiles = []float64{50, 85, 95}
+
+// issueFlow is the replayed status timeline of one issue
+type issueFlow struct {
+	Key          string
+	Summary      string
+	Status       string
+	Created      time.Time
+	Started      time.Time
+	Done         time.Time
+	LeadTime     time.Duration
+	CycleTime    time.Duration
+	TimeInStatus map[string]time.Duration
+	// SkippedHistories counts changelog entries left out because their timestamp could not be read
+	SkippedHistories int
+}
+
+func RegisterJiraFlowMetricsTool(s *server.MCPServer) {
+	jiraFlowMetricsTool := mcp.NewTool("flow_metrics",
+		mcp.WithDescription("Compute lead time, cycle time and time in status for issues matching a JQL query by replaying status transitions from their changelogs. Lead time runs from creation to done; cycle time runs from the first 'In Progress' category status to done. Returns percentiles and per-issue rows"),
+		mcp.WithString("jql", mcp.Required(), mcp.Description("JQL query selecting the issues to measure (e.g., 'project = KP AND resolved >= -30d')")),
+		mcp.WithNumber("max_issues", mcp.Description(fmt.Sprintf("Maximum number of issues to analyze (default: %d)", defaultFlowMaxIssues))),
+	)
+	s.AddTool(jiraFlowMetricsTool, mcp.NewTypedToolHandler(JiraFlowMetricsHandler))
+}
+
+func JiraFlowMetricsHandler(ctx context.Context, request mcp.CallToolRequest, input FlowMetricsInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	maxIssues := input.MaxIssues
+	if maxIssues <= 0 {
+		maxIssues = defaultFlowMaxIssues
+	}
+
+	categories, err := statusCategoriesByName(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := searchAllIssues(ctx, client, input.JQL, &jira.SearchOptions{
+		Fields: []string{"summary", "status", "created", "resolutiondate"},
+		Expand: "changelog",
+	}, maxIssues+1)
+	if err != nil {
+		return nil, err
+	}
+	// One issue past the limit tells whether the metrics cover every match
+	truncated := len(issues) > maxIssues
+	if truncated {
+		issues = issues[:maxIssues]
+	}
+
+	if len(issues) == 0 {
+		return mcp.NewToolResultText("No issues found matching the query."), nil
+	}
+
+	now := time.Now()
+	flows := make([]*issueFlow, 0, len(issues))
+	for index := range issues {
+		if err := util.LoadFullChangelog(ctx, client, &issues[index]); err != nil {
+			return nil, err
+		}
+		flows = append(flows, replayIssueFlow(&issues[index], categories, now))
+	}
+
+	return mcp.NewToolResultText(formatFlowMetrics(input.JQL, flows, truncated, maxIssues)), nil
+}
+
+// replayIssueFlow walks the status changelog of an issue and measures how long it spent in each status.
+// Time in the current status is only counted while the issue is not done
+func replayIssueFlow(issue *jira.Issue, categories map[string]string, now time.Time) *issueFlow {
+	flow := &issueFlow{
+		Key:          issue.Key,
+		TimeInStatus: make(map[string]time.Duration),
+	}
+	if issue.Fields == nil {
+		return flow
+	}
+
+	flow.Summary = issue.Fields.Summary
+	flow.Created = time.Time(issue.Fields.Created)
+	flow.SkippedHistories = util.SkippedHistories(issue)
+
+	currentCategory := ""
+	if issue.Fields.Status != nil {
+		flow.Status = issue.Fields.Status.Name
+		currentCategory = issue.Fields.Status.StatusCategory.Key
+	}
+	if currentCategory == "" {
+		currentCategory = categories[strings.ToLower(flow.Status)]
+	}
+
+	changes := util.FieldChanges(issue, "status")
+
+	status := flow.Status
+	if len(changes) > 0 {
+		status = changes[0].FromString
+	}
+	since := flow.Created
+
+	for _, change := range changes {
+		if change.At.After(since) {
+			flow.TimeInStatus[status] += change.At.Sub(since)
+		}
+
+		fromCategory := categories[strings.ToLower(change.FromString)]
+		toCategory := categories[strings.ToLower(change.ToString)]
+		if toCategory == "indeterminate" && flow.Started.IsZero() {
+			flow.Started = change.At
+		}
+		// The latest move into done counts, so reopened issues are measured to their final completion
+		if toCategory == "done" && fromCategory != "done" {
+			flow.Done = change.At
+		}
+
+		status = change.ToString
+		since = change.At
+	}
+
+	if currentCategory != "done" {
+		flow.Done = time.Time{}
+		if now.After(since) {
+			flow.TimeInStatus[status] += now.Sub(since)
+		}
+		return flow
+	}
+
+	if flow.Done.IsZero() {
+		// Created directly in a done status or the changelog is missing; fall back to the resolution date
+		flow.Done = time.Time(issue.Fields.Resolutiondate)
+	}
+	if !flow.Done.IsZero() && !flow.Created.IsZero() {
+		flow.LeadTime = flow.Done.Sub(flow.Created)
+	}
+	if !flow.Done.IsZero() && !flow.Started.IsZero() && flow.Done.After(flow.Started) {
+		flow.CycleTime = flow.Done.Sub(flow.Started)
+	}
+
+	return flow
+}
+
+func formatFlowMetrics(jql string, flows []*issueFlow, truncated bool, maxIssues int) string {
+	var leadTimes, cycleTimes []time.Duration
+	statusTotals := make(map[string]time.Duration)
+	statusIssues := make(map[string]int)
+	completed := 0
+	skipped := 0
+
+	for _, flow := range flows {
+		skipped += flow.SkippedHistories
+		if !flow.Done.IsZero() {
+			completed++
+			if flow.LeadTime > 0 {
+				leadTimes = append(leadTimes, flow.LeadTime)
+			}
+			if flow.CycleTime > 0 {
+				cycleTimes = append(cycleTimes, flow.CycleTime)
+			}
+		}
+		for status, duration := range flow.TimeInStatus {
+			statusTotals[status] += duration
+			statusIssues[status]++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Flow Metrics\n")
+	sb.WriteString(fmt.Sprintf("JQL: %s\n", jql))
+	sb.WriteString(fmt.Sprintf("Issues: %d (%d done, %d in progress or not started)\n", len(flows), completed, len(flows)-completed))
+	if truncated {
+		sb.WriteString(fmt.Sprintf("Note: only the first %d matching issues were analyzed; raise max_issues or narrow the query for complete metrics\n", maxIssues))
+	}
+	sb.WriteString(skippedHistoriesNote(skipped))
+
+	sb.WriteString("\nSummary (calendar days):\n")
+	sb.WriteString("| Metric | Issues | Average |")
+	for _, percentile := range flowPercentiles {
+		sb.WriteString(fmt.Sprintf(" P%.0f |", percentile))
+	}
+	sb.WriteString("\n|---|---|---|" + strings.Repeat("---|", len(flowPercentiles)) + "\n")
+	writeFlowSummaryRow(&sb, "Lead time", leadTimes)
+	writeFlowSummaryRow(&sb, "Cycle time", cycleTimes)
+
+	if len(statusTotals) > 0 {
+		statuses := make([]string, 0, len(statusTotals))
+		for status := range statusTotals {
+			statuses = append(statuses, status)
+		}
+		sort.Slice(statuses, func(i, j int) bool {
+			return statusTotals[statuses[i]] > statusTotals[statuses[j]]
+		})
+
+		sb.WriteString("\nTime in Status:\n| Status | Issues | Total | Average |\n|---|---|---|---|\n")
+		for _, status := range statuses {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s |\n", util.EscapeTableCell(status), statusIssues[status], formatDays(statusTotals[status]), formatDays(statusTotals[status]/time.Duration(statusIssues[status]))))
+		}
+	}
+
+	sb.WriteString("\nIssues:\n| Key | Summary | Status | Created | Done | Lead Time | Cycle Time |\n|---|---|---|---|---|---|---|\n")
+	for _, flow := range flows {
+		done, lead, cycle := "-", "-", "-"
+		if !flow.Done.IsZero() {
+			done = flow.Done.In(time.Local).Format("2006-01-02")
+		}
+		if flow.LeadTime > 0 {
+			lead = formatDays(flow.LeadTime)
+		}
+		if flow.CycleTime > 0 {
+			cycle = formatDays(flow.CycleTime)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s |\n", flow.Key, util.EscapeTableCell(flow.Summary), util.EscapeTableCell(flow.Status), flow.Created.In(time.Local).Format("2006-01-02"), done, lead, cycle))
+	}
+
+	return sb.String()
+}
+
+func writeFlowSummaryRow(sb *strings.Builder, label string, durations []time.Duration) {
+	sb.WriteString(fmt.Sprintf("| %s | %d |", label, len(durations)))
+	if len(durations) == 0 {
+		sb.WriteString(" - |" + strings.Repeat(" - |", len(flowPercentiles)) + "\n")
+		return
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, duration := range sorted {
+		total += duration
+	}
+	sb.WriteString(fmt.Sprintf(" %s |", formatDays(total/time.Duration(len(sorted)))))
+
+	for _, percentile := range flowPercentiles {
+		sb.WriteString(fmt.Sprintf(" %s |", formatDays(percentileDuration(sorted, percentile))))
+	}
+	sb.WriteString("\n")
+}
+
+// percentileDuration returns the nearest-rank percentile of an ascending slice
+func percentileDuration(sorted []time.Duration, percentile float64) time.Duration {
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// formatDays renders a duration as calendar days with one decimal
+func formatDays(duration time.Duration) string {
+	return fmt.Sprintf("%.1fd", duration.Hours()/24)
+}
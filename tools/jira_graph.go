@@ -0,0 +1,413 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type ExportIssueGraphInput struct {
+	IssueKey  string `json:"issue_key,omitempty"`
+	JQL       string `json:"jql,omitempty"`
+	Format    string `json:"format,omitempty"`
+	Depth     int    `json:"depth,omitempty"`
+	MaxIssues int    `json:"max_issues,omitempty"`
+}
+
+const (
+	// defaultGraphMaxIssues caps the number of nodes in an exported graph
+	defaultGraphMaxIssues = 100
+	// maxGraphDepth bounds how many hops the graph export follows from the starting issues
+	maxGraphDepth = 5
+)
+
+// graphFields are the issue fields needed to render a node and find its neighbours
+const graphFields = "summary,status,issuetype,issuelinks,subtasks"
+
+// statusCategoryColors holds fill, stroke and text colors per status category key
+var statusCategoryColors = map[string][3]string{
+	"new":           {"#dfe1e6", "#42526e", "#172b4d"},
+	"indeterminate": {"#deebff", "#0052cc", "#0747a6"},
+	"done":          {"#e3fcef", "#00875a", "#006644"},
+}
+
+// blockerColor highlights edges of the Blocks link type
+const blockerColor = "#de350b"
+
+// issueGraph is a directed graph of issues connected by links, subtasks and epic membership
+type issueGraph struct {
+	Nodes     []*issueNode
+	Edges     []issueEdge
+	nodeIndex map[string]*issueNode
+	edgeIndex map[string]bool
+}
+
+type issueNode struct {
+	Key      string
+	Summary  string
+	Status   string
+	Category string
+	Type     string
+}
+
+type issueEdge struct {
+	From    string
+	To      string
+	Label   string
+	Blocker bool
+}
+
+func RegisterJiraGraphTool(s *server.MCPServer) {
+	jiraExportIssueGraphTool := mcp.NewTool("export_issue_graph",
+		mcp.WithDescription("Export the dependency graph around issues as Mermaid or Graphviz DOT text. Follows issue links, subtasks and epic children to a configurable depth, colors nodes by status category and highlights blockers"),
+		mcp.WithString("issue_key", mcp.Description("Issue key(s) to start from, comma separated (e.g., KP-2 or KP-2,KP-7)")),
+		mcp.WithString("jql", mcp.Description("JQL query selecting the issues to start from (e.g., 'fixVersion = 1.4')")),
+		mcp.WithString("format", mcp.Description("Output format: 'mermaid' (default) or 'dot'")),
+		mcp.WithNumber("depth", mcp.Description(fmt.Sprintf("How many hops to follow from the starting issues (default: 2, max: %d)", maxGraphDepth))),
+		mcp.WithNumber("max_issues", mcp.Description(fmt.Sprintf("Maximum number of issues in the graph (default: %d)", defaultGraphMaxIssues))),
+	)
+	s.AddTool(jiraExportIssueGraphTool, mcp.NewTypedToolHandler(JiraExportIssueGraphHandler))
+}
+
+func JiraExportIssueGraphHandler(ctx context.Context, request mcp.CallToolRequest, input ExportIssueGraphInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	format := strings.ToLower(strings.TrimSpace(input.Format))
+	if format == "" {
+		format = "mermaid"
+	}
+	if format != "mermaid" && format != "dot" {
+		return nil, fmt.Errorf("invalid format %q: must be 'mermaid' or 'dot'", input.Format)
+	}
+
+	depth := input.Depth
+	if depth <= 0 {
+		depth = 2
+	}
+	if depth > maxGraphDepth {
+		depth = maxGraphDepth
+	}
+
+	maxIssues := input.MaxIssues
+	if maxIssues <= 0 {
+		maxIssues = defaultGraphMaxIssues
+	}
+
+	roots := parseIssueKeys(input.IssueKey)
+	if input.JQL != "" {
+		issues, err := searchAllIssues(ctx, client, input.JQL, &jira.SearchOptions{Fields: []string{"summary"}}, maxIssues)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			roots = append(roots, issue.Key)
+		}
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("either issue_key or a jql query matching at least one issue is required")
+	}
+
+	graph, truncated, err := buildIssueGraph(ctx, client, roots, depth, maxIssues)
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Issue graph: %d issues, %d edges (depth %d)\n", len(graph.Nodes), len(graph.Edges), depth))
+	if truncated {
+		sb.WriteString(fmt.Sprintf("Note: the graph was truncated at %d issues; raise max_issues or lower depth for the full picture\n", maxIssues))
+	}
+	sb.WriteString("\n")
+
+	if format == "dot" {
+		sb.WriteString("```dot\n")
+		sb.WriteString(graph.dot())
+	} else {
+		sb.WriteString("```mermaid\n")
+		sb.WriteString(graph.mermaid())
+	}
+	sb.WriteString("```\n")
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// epicDetector recognizes epics whose issue type has been renamed or localized. Jira Cloud reports
+// the hierarchy level of each issue type; on Jira Server only epics carry an Epic Name
+type epicDetector struct {
+	ctx             context.Context
+	client          *jira.Client
+	epicNameFieldID string
+	levels          map[string]*int
+}
+
+func newEpicDetector(ctx context.Context, client *jira.Client) (*epicDetector, error) {
+	epicNameFieldID, err := util.DiscoverEpicNameFieldID(ctx, client)
+	if err != nil && !errors.Is(err, util.ErrFieldNotFound) {
+		return nil, fmt.Errorf("failed to discover the Epic Name field: %v", err)
+	}
+	return &epicDetector{ctx: ctx, client: client, epicNameFieldID: epicNameFieldID, levels: make(map[string]*int)}, nil
+}
+
+func (d *epicDetector) isEpic(issue *jira.Issue) bool {
+	if level := d.level(issue.Fields.Type.ID); level != nil {
+		return *level == 1
+	}
+	if d.epicNameFieldID != "" {
+		return issue.Fields.Unknowns[d.epicNameFieldID] != nil
+	}
+	return strings.EqualFold(issue.Fields.Type.Name, "Epic")
+}
+
+// level returns the hierarchy level of an issue type, or nil where the instance does not report one
+func (d *epicDetector) level(issueTypeID string) *int {
+	if level, ok := d.levels[issueTypeID]; ok {
+		return level
+	}
+	d.levels[issueTypeID] = nil
+
+	req, err := d.client.NewRequestWithContext(d.ctx, "GET", fmt.Sprintf("rest/api/2/issuetype/%s", issueTypeID), nil)
+	if err != nil {
+		return nil
+	}
+	var issueType projectIssueType
+	if _, err := d.client.Do(req, &issueType); err != nil {
+		return nil
+	}
+	d.levels[issueTypeID] = issueType.HierarchyLevel
+	return issueType.HierarchyLevel
+}
+
+// buildIssueGraph walks breadth-first from the roots. Issues found at the depth limit are
+// included as nodes but not expanded further. truncated reports that maxIssues was reached
+func buildIssueGraph(ctx context.Context, client *jira.Client, roots []string, depth int, maxIssues int) (*issueGraph, bool, error) {
+	graph := &issueGraph{
+		nodeIndex: make(map[string]*issueNode),
+		edgeIndex: make(map[string]bool),
+	}
+
+	// The Epic Link field only exists in company-managed projects; parent covers the rest
+	epicLinkFieldID, err := util.DiscoverEpicLinkFieldID(ctx, client)
+	if err != nil && !errors.Is(err, util.ErrFieldNotFound) {
+		return nil, false, fmt.Errorf("failed to discover the Epic Link field: %v", err)
+	}
+
+	epics, err := newEpicDetector(ctx, client)
+	if err != nil {
+		return nil, false, err
+	}
+	fields := graphFields
+	if epics.epicNameFieldID != "" {
+		fields += "," + epics.epicNameFieldID
+	}
+
+	level := make(map[string]int)
+	var queue []string
+	for _, key := range roots {
+		if _, ok := level[key]; ok {
+			continue
+		}
+		level[key] = 0
+		queue = append(queue, key)
+	}
+
+	truncated := false
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+
+		issue, _, err := client.Issue.GetWithContext(ctx, key, &jira.GetQueryOptions{Fields: fields})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get issue %s: %v", key, err)
+		}
+		graph.addNode(issue)
+
+		if level[issue.Key] >= depth {
+			continue
+		}
+
+		neighbours := make([]*jira.Issue, 0)
+		for _, link := range issue.Fields.IssueLinks {
+			other, _ := linkEnd(link)
+			if other == nil {
+				continue
+			}
+			// Edges always point from the link's inward issue to its outward issue
+			from, to := issue.Key, other.Key
+			if link.OutwardIssue == nil {
+				from, to = other.Key, issue.Key
+			}
+			graph.addEdge(from, to, link.Type.Outward, strings.EqualFold(link.Type.Name, "Blocks"))
+			neighbours = append(neighbours, other)
+		}
+
+		for _, subtask := range issue.Fields.Subtasks {
+			graph.addEdge(issue.Key, subtask.Key, "subtask", false)
+			neighbours = append(neighbours, &jira.Issue{Key: subtask.Key, Fields: &subtask.Fields})
+		}
+
+		if epics.isEpic(issue) {
+			jql := fmt.Sprintf("parent = %s", issue.Key)
+			if epicLinkFieldID != "" {
+				jql = fmt.Sprintf(`"Epic Link" = %s OR parent = %s`, issue.Key, issue.Key)
+			}
+			children, err := searchAllIssues(ctx, client, jql, &jira.SearchOptions{
+				Fields: []string{"summary", "status", "issuetype"},
+			}, maxIssues)
+			if err != nil {
+				return nil, false, err
+			}
+			for index := range children {
+				graph.addEdge(issue.Key, children[index].Key, "epic child", false)
+				neighbours = append(neighbours, &children[index])
+			}
+		}
+
+		for _, neighbour := range neighbours {
+			if _, ok := level[neighbour.Key]; ok {
+				continue
+			}
+			if len(level) >= maxIssues {
+				truncated = true
+				continue
+			}
+			level[neighbour.Key] = level[issue.Key] + 1
+			if level[neighbour.Key] < depth {
+				queue = append(queue, neighbour.Key)
+			} else {
+				// Depth limit reached: the embedded fields are enough to render the node
+				graph.addNode(neighbour)
+			}
+		}
+	}
+
+	// Drop edges to issues that were cut off by max_issues
+	edges := graph.Edges[:0]
+	for _, edge := range graph.Edges {
+		if graph.nodeIndex[edge.From] != nil && graph.nodeIndex[edge.To] != nil {
+			edges = append(edges, edge)
+		}
+	}
+	graph.Edges = edges
+
+	return graph, truncated, nil
+}
+
+func (g *issueGraph) addNode(issue *jira.Issue) {
+	if _, ok := g.nodeIndex[issue.Key]; ok {
+		return
+	}
+
+	node := &issueNode{Key: issue.Key, Category: "new"}
+	if issue.Fields != nil {
+		node.Summary = issue.Fields.Summary
+		node.Type = issue.Fields.Type.Name
+		if issue.Fields.Status != nil {
+			node.Status = issue.Fields.Status.Name
+			if issue.Fields.Status.StatusCategory.Key != "" {
+				node.Category = issue.Fields.Status.StatusCategory.Key
+			}
+		}
+	}
+
+	g.nodeIndex[issue.Key] = node
+	g.Nodes = append(g.Nodes, node)
+}
+
+func (g *issueGraph) addEdge(from, to, label string, blocker bool) {
+	key := from + "|" + to + "|" + label
+	if g.edgeIndex[key] {
+		return
+	}
+	g.edgeIndex[key] = true
+	g.Edges = append(g.Edges, issueEdge{From: from, To: to, Label: label, Blocker: blocker})
+}
+
+func (g *issueGraph) mermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	byCategory := make(map[string][]string)
+	var categories []string
+	for _, node := range g.Nodes {
+		label := fmt.Sprintf("%s: %s", node.Key, node.Summary)
+		if node.Status != "" {
+			label += fmt.Sprintf("<br/>[%s]", node.Status)
+		}
+		sb.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", mermaidID(node.Key), strings.ReplaceAll(label, `"`, "#quot;")))
+
+		if _, ok := byCategory[node.Category]; !ok {
+			categories = append(categories, node.Category)
+		}
+		byCategory[node.Category] = append(byCategory[node.Category], mermaidID(node.Key))
+	}
+
+	var blockers []string
+	for index, edge := range g.Edges {
+		sb.WriteString(fmt.Sprintf("    %s -->|%s| %s\n", mermaidID(edge.From), strings.ReplaceAll(edge.Label, "|", "/"), mermaidID(edge.To)))
+		if edge.Blocker {
+			blockers = append(blockers, fmt.Sprint(index))
+		}
+	}
+
+	for _, category := range categories {
+		colors, ok := statusCategoryColors[category]
+		if !ok {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("    classDef %s fill:%s,stroke:%s,color:%s\n", category, colors[0], colors[1], colors[2]))
+		sb.WriteString(fmt.Sprintf("    class %s %s\n", strings.Join(byCategory[category], ","), category))
+	}
+
+	if len(blockers) > 0 {
+		sb.WriteString(fmt.Sprintf("    linkStyle %s stroke:%s,stroke-width:2px\n", strings.Join(blockers, ","), blockerColor))
+	}
+
+	return sb.String()
+}
+
+func (g *issueGraph) dot() string {
+	var sb strings.Builder
+	sb.WriteString("digraph issues {\n")
+	sb.WriteString("    rankdir=LR;\n")
+	sb.WriteString("    node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	for _, node := range g.Nodes {
+		label := fmt.Sprintf("%s\\n%s", dotEscape(node.Key), dotEscape(node.Summary))
+		if node.Status != "" {
+			label += fmt.Sprintf("\\n[%s]", dotEscape(node.Status))
+		}
+		attributes := fmt.Sprintf("label=\"%s\"", label)
+		if colors, ok := statusCategoryColors[node.Category]; ok {
+			attributes += fmt.Sprintf(", fillcolor=\"%s\", color=\"%s\", fontcolor=\"%s\"", colors[0], colors[1], colors[2])
+		}
+		sb.WriteString(fmt.Sprintf("    \"%s\" [%s];\n", dotEscape(node.Key), attributes))
+	}
+
+	for _, edge := range g.Edges {
+		attributes := fmt.Sprintf("label=\"%s\"", dotEscape(edge.Label))
+		if edge.Blocker {
+			attributes += fmt.Sprintf(", color=\"%s\", penwidth=2", blockerColor)
+		}
+		sb.WriteString(fmt.Sprintf("    \"%s\" -> \"%s\" [%s];\n", dotEscape(edge.From), dotEscape(edge.To), attributes))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// mermaidID turns an issue key into a valid Mermaid node identifier
+func mermaidID(key string) string {
+	return strings.ReplaceAll(key, "-", "_")
+}
+
+func dotEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(value)
+}
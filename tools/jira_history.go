@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type GetIssueHistoryInput struct {
+	IssueKey  string `json:"issue_key" validate:"required"`
+	Field     string `json:"field,omitempty"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+func RegisterJiraHistoryTool(s *server.MCPServer) {
+	jiraGetIssueHistoryTool := mcp.NewTool("get_issue_history",
+		mcp.WithDescription("Retrieve the change history of a Jira issue. Each entry shows the author, timestamp, field and the change from the old to the new value"),
+		mcp.WithString("issue_key", mcp.Required(), mcp.Description("The unique identifier of the Jira issue (e.g., KP-2, PROJ-123)")),
+		mcp.WithString("field", mcp.Description("Only show changes to these fields, comma separated (e.g., 'status', 'assignee,priority')")),
+		mcp.WithString("start_date", mcp.Description("Only show changes made on or after this date (e.g., '2024-01-15' or '2024-01-15T09:00:00')")),
+		mcp.WithString("end_date", mcp.Description("Only show changes made on or before this date (e.g., '2024-01-31'); a date without time includes the whole day")),
+	)
+	s.AddTool(jiraGetIssueHistoryTool, mcp.NewTypedToolHandler(JiraGetIssueHistoryHandler))
+}
+
+func JiraGetIssueHistoryHandler(ctx context.Context, request mcp.CallToolRequest, input GetIssueHistoryInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	var from, until time.Time
+	if input.StartDate != "" {
+		start, err := util.ParseDateTime(input.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_date: %v", err)
+		}
+		from = start
+	}
+	if input.EndDate != "" {
+		end, err := util.ParseDateTime(input.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_date: %v", err)
+		}
+		until = end
+		// A bare date covers the whole day
+		if !strings.Contains(input.EndDate, "T") && !strings.Contains(input.EndDate, ":") {
+			until = end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+	if !from.IsZero() && !until.IsZero() && until.Before(from) {
+		return nil, fmt.Errorf("end_date must not be before start_date")
+	}
+
+	issue, _, err := client.Issue.GetWithContext(ctx, input.IssueKey, &jira.GetQueryOptions{
+		Fields: "summary",
+		Expand: "changelog",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue history: %v", err)
+	}
+	if err := util.LoadFullChangelog(ctx, client, issue); err != nil {
+		return nil, fmt.Errorf("failed to get issue history: %v", err)
+	}
+	skipped := util.SkippedHistories(issue)
+
+	var fields []string
+	for _, field := range strings.Split(input.Field, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	var changes []util.FieldChange
+	for _, change := range util.FieldChanges(issue, "") {
+		if !from.IsZero() && change.At.Before(from) {
+			continue
+		}
+		if !until.IsZero() && change.At.After(until) {
+			continue
+		}
+		if len(fields) > 0 && !historyFieldMatches(change.Field, fields) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	if len(changes) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No history found for issue %s matching the criteria.\n%s", issue.Key, skippedHistoriesNote(skipped))), nil
+	}
+
+	var sb strings.Builder
+	summary := ""
+	if issue.Fields != nil {
+		summary = issue.Fields.Summary
+	}
+	sb.WriteString(fmt.Sprintf("History for %s: %s (%d changes)\n", issue.Key, summary, len(changes)))
+	sb.WriteString(skippedHistoriesNote(skipped))
+
+	// Changes made in the same history entry share author and timestamp; render them under one heading
+	for index, change := range changes {
+		if index == 0 || !change.At.Equal(changes[index-1].At) || change.Author != changes[index-1].Author {
+			author := change.Author
+			if author == "" {
+				author = "Unknown"
+			}
+			sb.WriteString(fmt.Sprintf("\n%s - %s\n", change.At.In(time.Local).Format("2006-01-02 15:04"), author))
+		}
+		sb.WriteString(fmt.Sprintf("  - %s: %s → %s\n", change.Field, historyValue(change.FromString, change.From), historyValue(change.ToString, change.To)))
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func historyFieldMatches(field string, fields []string) bool {
+	for _, candidate := range fields {
+		if strings.EqualFold(field, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// skippedHistoriesNote tells how many changelog entries were left out because their timestamp could not be read
+func skippedHistoriesNote(skipped int) string {
+	if skipped == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Note: %d changelog entries with unreadable timestamps were skipped\n", skipped)
+}
+
+// historyValue prefers the display string of a changelog value, falling back to its raw ID
+func historyValue(display string, raw string) string {
+	if display != "" {
+		return display
+	}
+	if raw != "" {
+		return raw
+	}
+	return "(none)"
+}
@@ -2,8 +2,10 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/andygrunwald/go-jira"
@@ -21,14 +23,22 @@ type GetIssueInput struct {
 }
 
 type CreateIssueInput struct {
-	ProjectKey  string `json:"project_key" validate:"required"`
-	Summary     string `json:"summary" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	IssueType   string `json:"issue_type" validate:"required"`
-	Assignee    string `json:"assignee,omitempty"`
-	Reporter    string `json:"reporter,omitempty"`
-	EpicName    string `json:"epic_name,omitempty"`
-	EpicLink    string `json:"epic_link,omitempty"`
+	ProjectKey   string                 `json:"project_key" validate:"required"`
+	Summary      string                 `json:"summary" validate:"required"`
+	Description  string                 `json:"description" validate:"required"`
+	IssueType    string                 `json:"issue_type" validate:"required"`
+	Assignee     string                 `json:"assignee,omitempty"`
+	Reporter     string                 `json:"reporter,omitempty"`
+	EpicName     string                 `json:"epic_name,omitempty"`
+	EpicLink     string                 `json:"epic_link,omitempty"`
+	Priority     string                 `json:"priority,omitempty"`
+	Components   string                 `json:"components,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+type GetCreateFieldsInput struct {
+	ProjectKey string `json:"project_key" validate:"required"`
+	IssueType  string `json:"issue_type,omitempty"`
 }
 
 type CreateChildIssueInput struct {
@@ -41,23 +51,34 @@ type CreateChildIssueInput struct {
 }
 
 type UpdateIssueInput struct {
-	IssueKey    string `json:"issue_key" validate:"required"`
-	Summary     string `json:"summary,omitempty"`
-	Description string `json:"description,omitempty"`
-	Assignee    string `json:"assignee,omitempty"`
-	Reporter    string `json:"reporter,omitempty"`
-	EpicLink    string `json:"epic_link,omitempty"`
+	IssueKey        string                 `json:"issue_key" validate:"required"`
+	Summary         string                 `json:"summary,omitempty"`
+	Description     string                 `json:"description,omitempty"`
+	Assignee        string                 `json:"assignee,omitempty"`
+	Reporter        string                 `json:"reporter,omitempty"`
+	EpicLink        string                 `json:"epic_link,omitempty"`
+	Labels          string                 `json:"labels,omitempty"`
+	Components      string                 `json:"components,omitempty"`
+	FixVersions     string                 `json:"fix_versions,omitempty"`
+	AffectsVersions string                 `json:"affects_versions,omitempty"`
+	Priority        string                 `json:"priority,omitempty"`
+	DueDate         string                 `json:"due_date,omitempty"`
+	Environment     string                 `json:"environment,omitempty"`
+	StoryPoints     *float64               `json:"story_points,omitempty"`
+	CustomFields    map[string]interface{} `json:"custom_fields,omitempty"`
+	Update          map[string]interface{} `json:"update,omitempty"`
 }
 
 type ListIssueTypesInput struct {
-	ProjectKey string `json:"project_key" validate:"required"`
+	ProjectKey      string `json:"project_key" validate:"required"`
+	IncludeStatuses bool   `json:"include_statuses,omitempty"`
 }
 
 func RegisterJiraIssueTool(s *server.MCPServer) {
 	jiraGetIssueTool := mcp.NewTool("get_issue",
 		mcp.WithDescription("Retrieve detailed information about a specific Jira issue including its status, assignee, description, subtasks, and available transitions"),
 		mcp.WithString("issue_key", mcp.Required(), mcp.Description("The unique identifier of the Jira issue (e.g., KP-2, PROJ-123)")),
-		mcp.WithString("fields", mcp.Description("Comma-separated list of fields to retrieve (e.g., 'summary,status,assignee'). If not specified, all fields are returned.")),
+		mcp.WithString("fields", mcp.Description("Comma-separated list of fields to retrieve by ID or display name (e.g., 'summary,status,assignee,Story Points'). If not specified, all fields are returned.")),
 		mcp.WithString("expand", mcp.Description("Comma-separated list of fields to expand for additional details (e.g., 'transitions,changelog,subtasks'). Default: 'transitions,changelog'")),
 	)
 	s.AddTool(jiraGetIssueTool, mcp.NewTypedToolHandler(JiraGetIssueHandler))
@@ -71,10 +92,20 @@ func RegisterJiraIssueTool(s *server.MCPServer) {
 		mcp.WithString("assignee", mcp.Description("Username or email of the person to assign the issue to (optional)")),
 		mcp.WithString("reporter", mcp.Description("Username or email of the person who reported the issue (optional)")),
 		mcp.WithString("epic_name", mcp.Description("Epic name (required when creating Epic issues; defaults to summary if not provided)")),
-		mcp.WithString("epic_link", mcp.Description("Epic key to link this issue to (e.g., EPIC-123)")),
+		mcp.WithString("epic_link", mcp.Description("Epic key to link this issue to (e.g., EPIC-123). Uses the Epic Link field in company-managed projects and the parent field in team-managed projects")),
+		mcp.WithString("priority", mcp.Description("Priority name (e.g., 'High'); validated against the project's allowed priorities")),
+		mcp.WithString("components", mcp.Description("Comma-separated component names (e.g., 'API, UI'); validated against the project's components")),
+		mcp.WithObject("custom_fields", mcp.Description("Custom field values by display name or ID, e.g. {\"Team\": \"Core\", \"Story Points\": 3}")),
 	)
 	s.AddTool(jiraCreateIssueTool, mcp.NewTypedToolHandler(JiraCreateIssueHandler))
 
+	jiraGetCreateFieldsTool := mcp.NewTool("get_create_fields",
+		mcp.WithDescription("List the issue types that can be created in a project, or the fields of an issue type's create screen with their types, required flags and allowed values. Use this to plan create_issue inputs"),
+		mcp.WithString("project_key", mcp.Required(), mcp.Description("Project identifier (e.g., KP, PROJ)")),
+		mcp.WithString("issue_type", mcp.Description("Issue type name or ID (e.g., 'Bug'). If omitted, the creatable issue types are listed")),
+	)
+	s.AddTool(jiraGetCreateFieldsTool, mcp.NewTypedToolHandler(JiraGetCreateFieldsHandler))
+
 	jiraCreateChildIssueTool := mcp.NewTool("create_child_issue",
 		mcp.WithDescription("Create a child issue (sub-task) linked to a parent issue in Jira. Returns the created issue's key, ID, and URL"),
 		mcp.WithString("parent_issue_key", mcp.Required(), mcp.Description("The parent issue key to which this child issue will be linked (e.g., KP-2)")),
@@ -93,13 +124,24 @@ func RegisterJiraIssueTool(s *server.MCPServer) {
 		mcp.WithString("description", mcp.Description("New description for the issue (optional). Supports Wiki Markup formatting")),
 		mcp.WithString("assignee", mcp.Description("Username or email of the person to assign the issue to (optional)")),
 		mcp.WithString("reporter", mcp.Description("Username or email of the person who reported the issue (optional)")),
-		mcp.WithString("epic_link", mcp.Description("Epic key to link this issue to (e.g., EPIC-123)")),
+		mcp.WithString("epic_link", mcp.Description("Epic key to link this issue to (e.g., EPIC-123). Uses the Epic Link field in company-managed projects and the parent field in team-managed projects")),
+		mcp.WithString("labels", mcp.Description("Comma-separated labels to add; prefix a label with '-' to remove it (e.g., 'backend, -legacy'). Existing labels are kept")),
+		mcp.WithString("components", mcp.Description("Comma-separated components to add; prefix with '-' to remove (e.g., 'API, -UI')")),
+		mcp.WithString("fix_versions", mcp.Description("Comma-separated fix versions to add; prefix with '-' to remove (e.g., '1.4.0, -1.3.9')")),
+		mcp.WithString("affects_versions", mcp.Description("Comma-separated affected versions to add; prefix with '-' to remove")),
+		mcp.WithString("priority", mcp.Description("New priority name (e.g., 'High')")),
+		mcp.WithString("due_date", mcp.Description("New due date (e.g., '2024-03-31')")),
+		mcp.WithString("environment", mcp.Description("New environment description")),
+		mcp.WithNumber("story_points", mcp.Description("New story point estimate")),
+		mcp.WithObject("custom_fields", mcp.Description("Custom field values to set, by display name or ID, e.g. {\"Team\": \"Core\", \"Story Points\": 3}")),
+		mcp.WithObject("update", mcp.Description("Other field updates by field ID or name using Jira update verbs, e.g. {\"labels\": {\"set\": [\"a\", \"b\"]}, \"Team\": {\"set\": \"Core\"}}. Verbs and values are validated against the issue's edit metadata")),
 	)
 	s.AddTool(jiraUpdateIssueTool, mcp.NewTypedToolHandler(JiraUpdateIssueHandler))
 
 	jiraListIssueTypesTool := mcp.NewTool("list_issue_types",
-		mcp.WithDescription("List all available issue types in a Jira project with their IDs, names, descriptions, and other attributes"),
+		mcp.WithDescription("List the issue types available in a Jira project with their IDs, names, descriptions and hierarchy level (epic, standard, subtask)"),
 		mcp.WithString("project_key", mcp.Required(), mcp.Description("Project identifier to list issue types for (e.g., KP, PROJ)")),
+		mcp.WithBoolean("include_statuses", mcp.Description("Also list the workflow statuses of each issue type (default: false)")),
 	)
 	s.AddTool(jiraListIssueTypesTool, mcp.NewTypedToolHandler(JiraListIssueTypesHandler))
 }
@@ -113,17 +155,29 @@ func JiraGetIssueHandler(ctx context.Context, request mcp.CallToolRequest, input
 		expand = input.Expand
 	}
 
+	// Resolve custom field names such as "Story Points" so only the requested custom fields are shown
+	var fields []string
+	if input.Fields != "" {
+		var err error
+		if fields, err = resolveSearchFields(ctx, client, input.Fields); err != nil {
+			return nil, err
+		}
+	}
+
 	issue, response, err := client.Issue.GetWithContext(ctx, input.IssueKey, &jira.GetQueryOptions{
 		Expand: expand,
-		Fields: input.Fields,
+		Fields: strings.Join(fields, ","),
 	})
 	if err != nil {
 		body, _ := io.ReadAll(response.Body)
 		return nil, fmt.Errorf("failed to get issue: %v, %s", err, string(body))
 	}
 
+	// Load the field registry so custom fields are shown by name; IDs are shown if it is unavailable
+	_, _ = util.LoadFields(ctx, client)
+
 	// Use the new util function to format the issue
-	formattedIssue := util.FormatJiraIssue(issue)
+	formattedIssue := util.FormatJiraIssueWithFields(issue, fields)
 
 	return mcp.NewToolResultText(formattedIssue), nil
 }
@@ -158,37 +212,96 @@ func JiraCreateIssueHandler(ctx context.Context, request mcp.CallToolRequest, in
 		}
 	}
 
+	// Team-managed (next-gen) projects have no Epic Name or Epic Link fields; epics are parents
+	teamManaged := false
+	if strings.ToLower(input.IssueType) == "epic" || input.EpicLink != "" {
+		var err error
+		teamManaged, err = isTeamManagedProject(ctx, client, input.ProjectKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Handle Epic Name for Epic issue types
-	if strings.ToLower(input.IssueType) == "epic" {
+	if strings.ToLower(input.IssueType) == "epic" && !teamManaged {
 		epicName := input.EpicName
 		if epicName == "" {
 			// Default to summary if no epic name is provided
 			epicName = input.Summary
 		}
 
-		// Initialize custom fields map if it doesn't exist
-		if issue.Fields.Unknowns == nil {
-			issue.Fields.Unknowns = make(map[string]interface{})
+		// Instances without an Epic Name field use the summary as the epic name
+		epicNameFieldID, err := util.DiscoverEpicNameFieldID(ctx, client)
+		if err != nil && !errors.Is(err, util.ErrFieldNotFound) {
+			return nil, fmt.Errorf("failed to discover the Epic Name field: %v", err)
+		}
+		if err == nil {
+			if issue.Fields.Unknowns == nil {
+				issue.Fields.Unknowns = make(map[string]interface{})
+			}
+			issue.Fields.Unknowns[epicNameFieldID] = epicName
 		}
-
-		// Set Epic Name - using the common custom field ID
-		// Note: This might vary between Jira instances
-		issue.Fields.Unknowns["customfield_10104"] = epicName
 	}
 
 	// Handle Epic Link for any issue type
 	if input.EpicLink != "" {
-		// Try to discover Epic Link field ID for classic projects
-		epicLinkFieldID, err := util.DiscoverEpicLinkFieldID(ctx, client)
+		if err := setEpicLink(ctx, client, issue, input.EpicLink, teamManaged); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate against the create screen so problems are reported by field instead of as a raw 400.
+	// When create metadata is unavailable the values are sent as-is and Jira validates them
+	createMeta, err := getCreateFields(ctx, client, input.ProjectKey, input.IssueType)
+	if errors.Is(err, errUnknownIssueType) {
+		return nil, err
+	}
+
+	// Fall back to the field schema when there is no create metadata to validate against
+	values := make(map[string]interface{})
+	metas := make(map[string]fieldMeta)
+	if input.Priority != "" {
+		values["priority"] = input.Priority
+		metas["priority"] = fieldMeta{Name: "Priority", Schema: jira.FieldSchema{Type: "priority"}}
+	}
+	if input.Components != "" {
+		values["components"] = input.Components
+		metas["components"] = fieldMeta{Name: "Component/s", Schema: jira.FieldSchema{Type: "array", Items: "component"}}
+	}
+
+	// Handle custom fields addressed by name
+	for name, value := range input.CustomFields {
+		field, err := util.ResolveField(ctx, client, name)
 		if err != nil {
-			return nil, fmt.Errorf("failed to discover epic link field ID: %v", err)
+			return nil, err
+		}
+		values[field.ID] = value
+		metas[field.ID] = fieldMeta{Name: field.Name, Schema: field.Schema}
+	}
+
+	for fieldID, value := range values {
+		meta := metas[fieldID]
+		if createMeta != nil {
+			screenMeta, ok := createMeta.Fields[fieldID]
+			if !ok {
+				return nil, fmt.Errorf("field %s (%s) is not on the create screen for %s in project %s. Available fields:\n%s", meta.Name, fieldID, createMeta.Name, input.ProjectKey, formatFieldMetas(createMeta.Fields))
+			}
+			meta = screenMeta
+		}
+		converted, err := fieldValue(&meta, value)
+		if err != nil {
+			return nil, err
 		}
-		// Use the discovered custom field ID for classic projects
 		if issue.Fields.Unknowns == nil {
 			issue.Fields.Unknowns = make(map[string]interface{})
 		}
-		issue.Fields.Unknowns[epicLinkFieldID] = input.EpicLink
+		issue.Fields.Unknowns[fieldID] = converted
+	}
 
+	if createMeta != nil {
+		if missing := missingCreateFields(createMeta.Fields, issue); len(missing) > 0 {
+			return nil, fmt.Errorf("missing required fields for %s in project %s; set them with custom_fields:\n%s", createMeta.Name, input.ProjectKey, formatFieldMetas(missing))
+		}
 	}
 
 	createdIssue, response, err := client.Issue.CreateWithContext(ctx, issue)
@@ -201,6 +314,66 @@ func JiraCreateIssueHandler(ctx context.Context, request mcp.CallToolRequest, in
 	return mcp.NewToolResultText(result), nil
 }
 
+// missingCreateFields returns the required create screen fields without a default value that the issue does not set
+func missingCreateFields(metas map[string]fieldMeta, issue *jira.Issue) map[string]fieldMeta {
+	// Project and issue type are always part of the request. Fields Jira fills itself, such as the reporter,
+	// are marked with a default value in the create metadata
+	provided := map[string]bool{
+		"project":     true,
+		"issuetype":   true,
+		"summary":     issue.Fields.Summary != "",
+		"description": issue.Fields.Description != "",
+		"assignee":    issue.Fields.Assignee != nil,
+		"reporter":    issue.Fields.Reporter != nil,
+		"parent":      issue.Fields.Parent != nil,
+	}
+	for fieldID := range issue.Fields.Unknowns {
+		provided[fieldID] = true
+	}
+
+	missing := make(map[string]fieldMeta)
+	for fieldID, meta := range metas {
+		if meta.Required && !meta.HasDefaultValue && !provided[fieldID] {
+			missing[fieldID] = meta
+		}
+	}
+	return missing
+}
+
+func JiraGetCreateFieldsHandler(ctx context.Context, request mcp.CallToolRequest, input GetCreateFieldsInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	if input.IssueType == "" {
+		issueTypes, err := getCreateIssueTypes(ctx, client, input.ProjectKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(issueTypes) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No issue types can be created in project %s.", input.ProjectKey)), nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("Issue types that can be created in project %s:\n", input.ProjectKey))
+		for _, issueType := range issueTypes {
+			subtask := ""
+			if issueType.Subtask {
+				subtask = " (Subtask Type)"
+			}
+			sb.WriteString(fmt.Sprintf("- %s (ID: %s)%s\n", issueType.Name, issueType.ID, subtask))
+		}
+		sb.WriteString("\nPass issue_type to list the fields of its create screen.")
+		return mcp.NewToolResultText(sb.String()), nil
+	}
+
+	issueType, err := getCreateFields(ctx, client, input.ProjectKey, input.IssueType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := fmt.Sprintf("Create screen fields for %s in project %s:\n%s", issueType.Name, input.ProjectKey, formatFieldMetas(issueType.Fields))
+	return mcp.NewToolResultText(result), nil
+}
+
 func JiraCreateChildIssueHandler(ctx context.Context, request mcp.CallToolRequest, input CreateChildIssueInput) (*mcp.CallToolResult, error) {
 	client := services.JiraClient()
 
@@ -256,7 +429,7 @@ func JiraCreateChildIssueHandler(ctx context.Context, request mcp.CallToolReques
 		createdIssue.Key, createdIssue.ID, createdIssue.Self, input.ParentIssueKey)
 
 	if issueType == "Bug" {
-		result += "\n\nA bug should be linked to a Story or Task. Next step should be to create relationship between the bug and the story or task."
+		result += "\n\nA bug should be linked to a Story or Task. Next step should be to link the bug to the story or task using link_issues."
 	}
 	return mcp.NewToolResultText(result), nil
 }
@@ -264,89 +437,351 @@ func JiraCreateChildIssueHandler(ctx context.Context, request mcp.CallToolReques
 func JiraUpdateIssueHandler(ctx context.Context, request mcp.CallToolRequest, input UpdateIssueInput) (*mcp.CallToolResult, error) {
 	client := services.JiraClient()
 
-	issue := &jira.Issue{
-		Key:    input.IssueKey,
-		Fields: &jira.IssueFields{},
-	}
+	fields := make(map[string]interface{})
 
 	if input.Summary != "" {
-		issue.Fields.Summary = input.Summary
+		fields["summary"] = input.Summary
 	}
 
 	if input.Description != "" {
-		issue.Fields.Description = input.Description
+		fields["description"] = input.Description
 	}
 
 	if input.Assignee != "" {
-		issue.Fields.Assignee = &jira.User{
-			Name: input.Assignee,
-		}
+		fields["assignee"] = map[string]interface{}{"name": input.Assignee}
 	}
 
 	if input.Reporter != "" {
-		issue.Fields.Reporter = &jira.User{
-			Name: input.Reporter,
-		}
+		fields["reporter"] = map[string]interface{}{"name": input.Reporter}
 	}
 
 	// Handle Epic Link
 	if input.EpicLink != "" {
-		// Try to discover Epic Link field ID for classic projects
-		epicLinkFieldID, err := util.DiscoverEpicLinkFieldID(ctx, client)
+		projectKey := input.IssueKey
+		if index := strings.LastIndex(projectKey, "-"); index > 0 {
+			projectKey = projectKey[:index]
+		}
+		teamManaged, err := isTeamManagedProject(ctx, client, projectKey)
 		if err != nil {
-			return nil, fmt.Errorf("failed to discover epic link field ID: %v", err)
+			return nil, err
 		}
-		// Use the discovered custom field ID for classic projects
-		if issue.Fields.Unknowns == nil {
-			issue.Fields.Unknowns = make(map[string]interface{})
+
+		epic := &jira.Issue{Fields: &jira.IssueFields{}}
+		if err := setEpicLink(ctx, client, epic, input.EpicLink, teamManaged); err != nil {
+			return nil, err
 		}
-		issue.Fields.Unknowns[epicLinkFieldID] = input.EpicLink
+		if epic.Fields.Parent != nil {
+			fields["parent"] = map[string]interface{}{"key": epic.Fields.Parent.Key}
+		}
+		for fieldID, value := range epic.Fields.Unknowns {
+			fields[fieldID] = value
+		}
+	}
 
+	updates, err := buildFieldUpdates(ctx, client, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 && len(updates) == 0 {
+		return nil, fmt.Errorf("no fields to update were provided")
+	}
+
+	payload := make(map[string]interface{})
+	if len(fields) > 0 {
+		payload["fields"] = fields
+	}
+	if len(updates) > 0 {
+		payload["update"] = updates
 	}
 
-	_, response, err := client.Issue.UpdateWithContext(ctx, issue)
+	response, err := client.Issue.UpdateIssueWithContext(ctx, input.IssueKey, payload)
 	if err != nil {
-		body, _ := io.ReadAll(response.Body)
-		return nil, fmt.Errorf("failed to update issue: %v, %s", err, string(body))
+		return nil, fmt.Errorf("failed to update issue: %v", jira.NewJiraError(response, err))
 	}
 
 	return mcp.NewToolResultText("Issue updated successfully!"), nil
 }
 
+// setEpicLink links an issue to an epic. Company-managed projects use the Epic Link field;
+// team-managed projects, and instances where Epic Link has been retired, use the parent field
+func setEpicLink(ctx context.Context, client *jira.Client, issue *jira.Issue, epicKey string, teamManaged bool) error {
+	if !teamManaged {
+		epicLinkFieldID, err := util.DiscoverEpicLinkFieldID(ctx, client)
+		if err == nil {
+			if issue.Fields.Unknowns == nil {
+				issue.Fields.Unknowns = make(map[string]interface{})
+			}
+			issue.Fields.Unknowns[epicLinkFieldID] = epicKey
+			return nil
+		}
+		if !errors.Is(err, util.ErrFieldNotFound) {
+			return fmt.Errorf("failed to discover the Epic Link field: %v", err)
+		}
+	}
+
+	issue.Fields.Parent = &jira.Parent{Key: epicKey}
+	return nil
+}
+
+// isTeamManagedProject reports whether a project is team-managed (next-gen)
+func isTeamManagedProject(ctx context.Context, client *jira.Client, projectKey string) (bool, error) {
+	req, err := client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("rest/api/2/project/%s", projectKey), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	var project struct {
+		Style      string `json:"style"`
+		Simplified bool   `json:"simplified"`
+	}
+	response, err := client.Do(req, &project)
+	if err != nil {
+		return false, fmt.Errorf("failed to get project: %v", jira.NewJiraError(response, err))
+	}
+
+	return project.Simplified || project.Style == "next-gen", nil
+}
+
+// buildFieldUpdates turns the field shortcuts and the update map into Jira update operations
+// Fields, verbs and values are validated against the issue's edit metadata
+func buildFieldUpdates(ctx context.Context, client *jira.Client, input UpdateIssueInput) (map[string][]map[string]interface{}, error) {
+	multiValue := []struct {
+		field string
+		value string
+	}{
+		{"labels", input.Labels},
+		{"components", input.Components},
+		{"fixVersions", input.FixVersions},
+		{"versions", input.AffectsVersions},
+	}
+	singleValue := []struct {
+		field string
+		value string
+	}{
+		{"priority", input.Priority},
+		{"duedate", input.DueDate},
+		{"environment", input.Environment},
+	}
+
+	hasUpdates := len(input.Update) > 0 || len(input.CustomFields) > 0 || input.StoryPoints != nil
+	for _, shortcut := range append(multiValue, singleValue...) {
+		if strings.TrimSpace(shortcut.value) != "" {
+			hasUpdates = true
+		}
+	}
+	if !hasUpdates {
+		return nil, nil
+	}
+
+	editMeta, err := getEditMeta(ctx, client, input.IssueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string][]map[string]interface{})
+	addOperation := func(name string, verb string, value interface{}) error {
+		fieldID, meta := findFieldMeta(editMeta, name)
+		if meta == nil {
+			return fmt.Errorf("field %q cannot be edited on issue %s. Editable fields:\n%s", name, input.IssueKey, formatFieldMetas(editMeta))
+		}
+		if len(meta.Operations) > 0 && !containsFold(meta.Operations, verb) {
+			return fmt.Errorf("field %s does not support '%s'. Supported operations: %s", meta.Name, verb, strings.Join(meta.Operations, ", "))
+		}
+
+		// add and remove work on single items of a multi-value field
+		if (verb == "add" || verb == "remove") && meta.Schema.Type == "array" {
+			items, ok := value.([]interface{})
+			if !ok {
+				items = []interface{}{value}
+			}
+			for _, item := range items {
+				converted := item
+				if text, ok := item.(string); ok {
+					if converted, err = fieldItemValue(meta, meta.Schema.Items, strings.TrimSpace(text)); err != nil {
+						return err
+					}
+				}
+				updates[fieldID] = append(updates[fieldID], map[string]interface{}{verb: converted})
+			}
+			return nil
+		}
+
+		converted, err := fieldValue(meta, value)
+		if err != nil {
+			return err
+		}
+		updates[fieldID] = append(updates[fieldID], map[string]interface{}{verb: converted})
+		return nil
+	}
+
+	for _, shortcut := range multiValue {
+		for _, item := range strings.Split(shortcut.value, ",") {
+			item = strings.TrimSpace(item)
+			verb := "add"
+			if strings.HasPrefix(item, "-") {
+				verb = "remove"
+			}
+			item = strings.TrimSpace(strings.TrimLeft(item, "+-"))
+			if item == "" {
+				continue
+			}
+			if err := addOperation(shortcut.field, verb, item); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, shortcut := range singleValue {
+		if strings.TrimSpace(shortcut.value) == "" {
+			continue
+		}
+		if err := addOperation(shortcut.field, "set", strings.TrimSpace(shortcut.value)); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.StoryPoints != nil {
+		storyPoints, err := util.DiscoverStoryPointsField(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		if err := addOperation(storyPoints.ID, "set", *input.StoryPoints); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, value := range input.CustomFields {
+		field, err := util.ResolveField(ctx, client, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := addOperation(field.ID, "set", value); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, spec := range input.Update {
+		switch operations := spec.(type) {
+		case map[string]interface{}:
+			for verb, value := range operations {
+				if err := addOperation(name, strings.ToLower(verb), value); err != nil {
+					return nil, err
+				}
+			}
+		case []interface{}:
+			// Already in Jira's list form: [{"add": "x"}, {"remove": "y"}]
+			for _, operation := range operations {
+				entry, ok := operation.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("invalid update for %s: expected objects like {\"add\": value}", name)
+				}
+				for verb, value := range entry {
+					if err := addOperation(name, strings.ToLower(verb), value); err != nil {
+						return nil, err
+					}
+				}
+			}
+		default:
+			if err := addOperation(name, "set", spec); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return updates, nil
+}
+
+// projectIssueType is an issue type of a project; hierarchyLevel is only reported by Jira Cloud
+type projectIssueType struct {
+	jira.IssueType
+	HierarchyLevel *int `json:"hierarchyLevel"`
+}
+
+// level returns the hierarchy level of the issue type: 1 for epics, 0 for standard types, -1 for subtasks.
+// Without a reported level it is derived from the subtask flag and the Epic type name
+func (t projectIssueType) level() int {
+	switch {
+	case t.HierarchyLevel != nil:
+		return *t.HierarchyLevel
+	case t.Subtask:
+		return -1
+	case strings.EqualFold(t.Name, "epic"):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func hierarchyName(level int) string {
+	switch {
+	case level < 0:
+		return "subtask"
+	case level == 0:
+		return "standard"
+	case level == 1:
+		return "epic"
+	default:
+		return fmt.Sprintf("level %d (above epic)", level)
+	}
+}
+
 func JiraListIssueTypesHandler(ctx context.Context, request mcp.CallToolRequest, input ListIssueTypesInput) (*mcp.CallToolResult, error) {
 	client := services.JiraClient()
 
-	req, err := client.NewRequest("GET", "rest/api/2/issuetype", nil)
+	req, err := client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("rest/api/2/project/%s", input.ProjectKey), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
 
-	var issueTypes []jira.IssueType
-	_, err = client.Do(req, &issueTypes)
+	var project struct {
+		Key        string             `json:"key"`
+		IssueTypes []projectIssueType `json:"issueTypes"`
+	}
+	response, err := client.Do(req, &project)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get issue types: %v", err)
+		return nil, fmt.Errorf("failed to get issue types: %v", jira.NewJiraError(response, err))
 	}
 
-	if len(issueTypes) == 0 {
+	if len(project.IssueTypes) == 0 {
 		return mcp.NewToolResultText("No issue types found for this project."), nil
 	}
 
-	var result strings.Builder
-	result.WriteString("Available Issue Types:\n\n")
+	// Highest hierarchy level first: epics, then standard types, then subtasks
+	issueTypes := project.IssueTypes
+	sort.SliceStable(issueTypes, func(i, j int) bool {
+		return issueTypes[i].level() > issueTypes[j].level()
+	})
 
-	for _, issueType := range issueTypes {
-		subtaskType := ""
-		if issueType.Subtask {
-			subtaskType = " (Subtask Type)"
+	statuses := make(map[string][]jira.Status)
+	if input.IncludeStatuses {
+		projectStatuses, err := getProjectStatuses(ctx, client, input.ProjectKey)
+		if err != nil {
+			return nil, err
 		}
+		for _, issueType := range projectStatuses {
+			statuses[issueType.ID] = issueType.Statuses
+		}
+	}
 
-		result.WriteString(fmt.Sprintf("ID: %s\nName: %s%s\n", issueType.ID, issueType.Name, subtaskType))
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Issue Types in project %s:\n\n", project.Key))
+
+	for _, issueType := range issueTypes {
+		result.WriteString(fmt.Sprintf("ID: %s\nName: %s\nHierarchy: %s\n", issueType.ID, issueType.Name, hierarchyName(issueType.level())))
 		if issueType.Description != "" {
 			result.WriteString(fmt.Sprintf("Description: %s\n", issueType.Description))
 		}
 		if issueType.IconURL != "" {
 			result.WriteString(fmt.Sprintf("Icon URL: %s\n", issueType.IconURL))
 		}
+		if input.IncludeStatuses {
+			names := make([]string, 0, len(statuses[issueType.ID]))
+			for _, status := range statuses[issueType.ID] {
+				names = append(names, fmt.Sprintf("%s [%s]", status.Name, status.StatusCategory.Name))
+			}
+			result.WriteString(fmt.Sprintf("Statuses: %s\n", strings.Join(names, ", ")))
+		}
 		result.WriteString("\n")
 	}
 
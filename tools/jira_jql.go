@@ -0,0 +1,340 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type ValidateJQLInput struct {
+	JQL string `json:"jql" validate:"required"`
+}
+
+// maxJQLSuggestions caps the number of alternatives suggested per problem
+const maxJQLSuggestions = 3
+
+// jqlParseUnsupported is set once the instance answers the JQL parse endpoint with 404;
+// Jira Server and Data Center only validate queries through search
+var jqlParseUnsupported atomic.Bool
+
+// Patterns of the JQL errors Jira reports for unknown fields, values and functions
+var (
+	jqlUnknownField    = regexp.MustCompile(`(?i)field '([^']+)' does not exist`)
+	jqlUnknownValue    = regexp.MustCompile(`(?i)value '([^']+)' does not exist for the field '([^']+)'`)
+	jqlUnknownFunction = regexp.MustCompile(`(?i)unable to find JQL function '([^'(]+)`)
+)
+
+// jqlAutocompleteData lists the fields and functions that can be used in JQL
+type jqlAutocompleteData struct {
+	VisibleFieldNames []struct {
+		Value       string `json:"value"`
+		DisplayName string `json:"displayName"`
+	} `json:"visibleFieldNames"`
+	VisibleFunctionNames []struct {
+		Value string `json:"value"`
+	} `json:"visibleFunctionNames"`
+}
+
+func RegisterJiraJQLTool(s *server.MCPServer) {
+	jiraValidateJQLTool := mcp.NewTool("validate_jql",
+		mcp.WithDescription("Check a JQL query without running it. Reports every unknown field, value and function with the closest valid names, so the query can be fixed before calling search_issue"),
+		mcp.WithString("jql", mcp.Required(), mcp.Description("JQL query to validate (e.g., 'project = KP AND status = \"In Progress\"')")),
+	)
+	s.AddTool(jiraValidateJQLTool, mcp.NewTypedToolHandler(JiraValidateJQLHandler))
+}
+
+func JiraValidateJQLHandler(ctx context.Context, request mcp.CallToolRequest, input ValidateJQLInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	problems, err := validateJQL(ctx, client, input.JQL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(problems) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("JQL is valid: %s", input.JQL)), nil
+	}
+
+	result := fmt.Sprintf("JQL has %d problem(s):\n%s", len(problems), formatJQLProblems(ctx, client, problems))
+	return mcp.NewToolResultText(result), nil
+}
+
+// validateJQL returns the problems Jira reports for a query; none means the query is valid.
+// It uses the JQL parse endpoint and falls back to a strict search for one issue where that is unavailable
+func validateJQL(ctx context.Context, client *jira.Client, jql string) ([]string, error) {
+	problems, supported, err := parseJQL(ctx, client, jql)
+	if supported {
+		return problems, err
+	}
+
+	_, _, err = client.Issue.SearchWithContext(ctx, jql, &jira.SearchOptions{
+		MaxResults:    1,
+		Fields:        []string{"key"},
+		ValidateQuery: "strict",
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if problems := jqlErrorMessages(err); len(problems) > 0 {
+		return problems, nil
+	}
+	return nil, fmt.Errorf("failed to validate JQL: %v", err)
+}
+
+// searchFailure explains a failed search. When Jira rejected the query, every problem is listed with the
+// closest valid names; Jira Cloud's parse endpoint is asked for the full list since a failed search may stop
+// at the first problem. The parse request is only made once a search has failed
+func searchFailure(ctx context.Context, client *jira.Client, jql string, err error) error {
+	problems := jqlErrorMessages(err)
+	if len(problems) == 0 {
+		return fmt.Errorf("failed to search issues: %v%s", err, searchErrorHint(err))
+	}
+
+	parsed, supported, parseErr := parseJQL(ctx, client, jql)
+	if supported && parseErr != nil {
+		return fmt.Errorf("failed to search issues: %v (validating the query also failed: %v)%s", err, parseErr, searchErrorHint(err))
+	}
+	if len(parsed) > 0 {
+		problems = parsed
+	}
+
+	return fmt.Errorf("failed to search issues:\n%s%s", formatJQLProblems(ctx, client, problems), searchErrorHint(err))
+}
+
+// parseJQL validates a query with the JQL parse endpoint of Jira Cloud.
+// supported is false when the instance has no parse endpoint
+func parseJQL(ctx context.Context, client *jira.Client, jql string) (problems []string, supported bool, err error) {
+	if jqlParseUnsupported.Load() {
+		return nil, false, nil
+	}
+
+	body := map[string][]string{"queries": {jql}}
+	req, err := client.NewRequestWithContext(ctx, "POST", "rest/api/2/jql/parse?validation=strict", body)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	var result struct {
+		Queries []struct {
+			Query  string   `json:"query"`
+			Errors []string `json:"errors"`
+		} `json:"queries"`
+	}
+	response, err := client.Do(req, &result)
+	if err != nil {
+		if response != nil && (response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusMethodNotAllowed) {
+			jqlParseUnsupported.Store(true)
+			return nil, false, nil
+		}
+		return nil, true, fmt.Errorf("failed to parse JQL: %v", jira.NewJiraError(response, err))
+	}
+
+	for _, query := range result.Queries {
+		problems = append(problems, query.Errors...)
+	}
+	return problems, true, nil
+}
+
+// jqlErrorMessages extracts every message of a Jira error response; the error's text only carries the first one
+func jqlErrorMessages(err error) []string {
+	var jiraErr *jira.Error
+	if !errors.As(err, &jiraErr) {
+		return nil
+	}
+
+	messages := append([]string{}, jiraErr.ErrorMessages...)
+	for _, message := range jiraErr.Errors {
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// formatJQLProblems lists JQL problems, each followed by the closest valid names where they can be found
+func formatJQLProblems(ctx context.Context, client *jira.Client, problems []string) string {
+	catalog := &jqlCatalog{ctx: ctx, client: client}
+
+	var sb strings.Builder
+	for _, problem := range problems {
+		sb.WriteString(fmt.Sprintf("- %s\n", problem))
+		if suggestions := catalog.suggest(problem); len(suggestions) > 0 {
+			sb.WriteString(fmt.Sprintf("  Did you mean: %s?\n", strings.Join(suggestions, ", ")))
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// jqlCatalog lazily loads the valid field, function and value names that suggestions are drawn from.
+// Catalogs that cannot be loaded simply yield no suggestions
+type jqlCatalog struct {
+	ctx          context.Context
+	client       *jira.Client
+	autocomplete *jqlAutocompleteData
+}
+
+func (c *jqlCatalog) suggest(problem string) []string {
+	if match := jqlUnknownValue.FindStringSubmatch(problem); match != nil {
+		return closestMatches(match[1], c.values(match[2]), maxJQLSuggestions)
+	}
+	if match := jqlUnknownField.FindStringSubmatch(problem); match != nil {
+		return closestMatches(match[1], c.fields(), maxJQLSuggestions)
+	}
+	if match := jqlUnknownFunction.FindStringSubmatch(problem); match != nil {
+		return closestMatches(strings.TrimSpace(match[1]), c.functions(), maxJQLSuggestions)
+	}
+	return nil
+}
+
+func (c *jqlCatalog) loadAutocomplete() *jqlAutocompleteData {
+	if c.autocomplete != nil {
+		return c.autocomplete
+	}
+	c.autocomplete = &jqlAutocompleteData{}
+
+	req, err := c.client.NewRequestWithContext(c.ctx, "GET", "rest/api/2/jql/autocompletedata", nil)
+	if err != nil {
+		return c.autocomplete
+	}
+	_, _ = c.client.Do(req, c.autocomplete)
+	return c.autocomplete
+}
+
+func (c *jqlCatalog) fields() []string {
+	var names []string
+	for _, field := range c.loadAutocomplete().VisibleFieldNames {
+		names = append(names, field.Value)
+	}
+
+	// The field registry adds the display names of fields that autocomplete lists by clause name
+	if fields, err := util.LoadFields(c.ctx, c.client); err == nil {
+		for _, field := range fields {
+			if field.Searchable {
+				names = append(names, field.Name)
+			}
+		}
+	}
+	return names
+}
+
+func (c *jqlCatalog) functions() []string {
+	var names []string
+	for _, function := range c.loadAutocomplete().VisibleFunctionNames {
+		names = append(names, function.Value)
+	}
+	return names
+}
+
+func (c *jqlCatalog) values(field string) []string {
+	var names []string
+	switch strings.ToLower(strings.Trim(field, `"`)) {
+	case "status":
+		statuses, _, err := c.client.Status.GetAllStatusesWithContext(c.ctx)
+		if err != nil {
+			return nil
+		}
+		for _, status := range statuses {
+			names = append(names, status.Name)
+		}
+	case "statuscategory":
+		names = []string{"To Do", "In Progress", "Done"}
+	case "priority":
+		priorities, _, err := c.client.Priority.GetListWithContext(c.ctx)
+		if err != nil {
+			return nil
+		}
+		for _, priority := range priorities {
+			names = append(names, priority.Name)
+		}
+	case "resolution":
+		resolutions, _, err := c.client.Resolution.GetListWithContext(c.ctx)
+		if err != nil {
+			return nil
+		}
+		for _, resolution := range resolutions {
+			names = append(names, resolution.Name)
+		}
+	}
+	return names
+}
+
+// closestMatches returns up to limit candidates within a small edit distance of value, closest first.
+// Names that look like a mistyped version of value are kept; unrelated names are dropped
+func closestMatches(value string, candidates []string, limit int) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+
+	target := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(value), "()"))
+	threshold := len(target) / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+
+	seen := make(map[string]bool)
+	var matches []match
+	for _, candidate := range candidates {
+		key := strings.ToLower(strings.TrimSuffix(candidate, "()"))
+		if candidate == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		distance := levenshtein(target, key)
+		if distance <= threshold {
+			matches = append(matches, match{name: candidate, distance: distance})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	var names []string
+	for index := 0; index < len(matches) && index < limit; index++ {
+		name := matches[index].name
+		if strings.ContainsAny(name, " -") && !strings.HasSuffix(name, ")") {
+			name = quoteJQL(name)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// levenshtein is the number of single-character insertions, deletions and substitutions between two strings
+func levenshtein(a string, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
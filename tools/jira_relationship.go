@@ -0,0 +1,321 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type LinkIssuesInput struct {
+	IssueKey       string `json:"issue_key" validate:"required"`
+	LinkType       string `json:"link_type" validate:"required"`
+	TargetIssueKey string `json:"target_issue_key" validate:"required"`
+	Comment        string `json:"comment,omitempty"`
+}
+
+type DeleteIssueLinkInput struct {
+	LinkID         string `json:"link_id,omitempty"`
+	IssueKey       string `json:"issue_key,omitempty"`
+	TargetIssueKey string `json:"target_issue_key,omitempty"`
+	LinkType       string `json:"link_type,omitempty"`
+}
+
+type ListLinkTypesInput struct{}
+
+type GetRelatedIssuesInput struct {
+	IssueKey string `json:"issue_key" validate:"required"`
+	LinkType string `json:"link_type,omitempty"`
+	Depth    int    `json:"depth,omitempty"`
+}
+
+// maxRelatedIssuesDepth bounds how far get_related_issues follows links
+const maxRelatedIssuesDepth = 5
+
+// relatedIssue is an issue reached while walking issue links
+type relatedIssue struct {
+	Key      string
+	Summary  string
+	Status   string
+	Relation string
+	Parent   string
+	Depth    int
+}
+
+func RegisterJiraRelationshipTool(s *server.MCPServer) {
+	jiraLinkIssuesTool := mcp.NewTool("link_issues",
+		mcp.WithDescription("Create a link between two issues. The link reads as '<issue_key> <link_type> <target_issue_key>', e.g. 'KP-1 blocks KP-2'. Use list_link_types to see valid link types"),
+		mcp.WithString("issue_key", mcp.Required(), mcp.Description("The issue the relationship starts from (e.g., KP-1)")),
+		mcp.WithString("link_type", mcp.Required(), mcp.Description("Link type name or direction phrase (e.g., 'Blocks', 'blocks', 'is blocked by', 'relates to', 'duplicates')")),
+		mcp.WithString("target_issue_key", mcp.Required(), mcp.Description("The issue the relationship points to (e.g., KP-2)")),
+		mcp.WithString("comment", mcp.Description("Optional comment to add to the issue when linking")),
+	)
+	s.AddTool(jiraLinkIssuesTool, mcp.NewTypedToolHandler(JiraLinkIssuesHandler))
+
+	jiraDeleteIssueLinkTool := mcp.NewTool("delete_issue_link",
+		mcp.WithDescription("Delete an issue link, either by its link ID or by the two linked issue keys"),
+		mcp.WithString("link_id", mcp.Description("ID of the issue link to delete")),
+		mcp.WithString("issue_key", mcp.Description("One of the linked issues (used when link_id is not known)")),
+		mcp.WithString("target_issue_key", mcp.Description("The other linked issue (used when link_id is not known)")),
+		mcp.WithString("link_type", mcp.Description("Only delete links of this type between the two issues (optional)")),
+	)
+	s.AddTool(jiraDeleteIssueLinkTool, mcp.NewTypedToolHandler(JiraDeleteIssueLinkHandler))
+
+	jiraListLinkTypesTool := mcp.NewTool("list_link_types",
+		mcp.WithDescription("List all issue link types available in Jira with their inward and outward descriptions"),
+	)
+	s.AddTool(jiraListLinkTypesTool, mcp.NewTypedToolHandler(JiraListLinkTypesHandler))
+
+	jiraGetRelatedIssuesTool := mcp.NewTool("get_related_issues",
+		mcp.WithDescription("Retrieve issues linked to an issue, following links transitively up to a configurable depth"),
+		mcp.WithString("issue_key", mcp.Required(), mcp.Description("The unique identifier of the Jira issue (e.g., KP-2, PROJ-123)")),
+		mcp.WithString("link_type", mcp.Description("Only follow links of this type or direction (e.g., 'Blocks', 'is blocked by')")),
+		mcp.WithNumber("depth", mcp.Description(fmt.Sprintf("How many link hops to follow (default: 1, max: %d)", maxRelatedIssuesDepth))),
+	)
+	s.AddTool(jiraGetRelatedIssuesTool, mcp.NewTypedToolHandler(JiraGetRelatedIssuesHandler))
+}
+
+func JiraLinkIssuesHandler(ctx context.Context, request mcp.CallToolRequest, input LinkIssuesInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	linkTypes, _, err := client.IssueLinkType.GetListWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue link types: %v", err)
+	}
+
+	linkType, reversed := matchLinkType(linkTypes, input.LinkType)
+	if linkType == nil {
+		return nil, fmt.Errorf("unknown link type %q. Available link types:\n%s", input.LinkType, formatLinkTypes(linkTypes))
+	}
+
+	// The inward issue of a link is its source: "<inward> <outward description> <outward>"
+	source, destination := input.IssueKey, input.TargetIssueKey
+	if reversed {
+		source, destination = destination, source
+	}
+
+	issueLink := &jira.IssueLink{
+		Type:         jira.IssueLinkType{Name: linkType.Name},
+		InwardIssue:  &jira.Issue{Key: source},
+		OutwardIssue: &jira.Issue{Key: destination},
+	}
+
+	if input.Comment != "" {
+		issueLink.Comment = &jira.Comment{Body: input.Comment}
+	}
+
+	response, err := client.Issue.AddLinkWithContext(ctx, issueLink)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link issues: %v", err)
+	}
+	response.Body.Close()
+
+	result := fmt.Sprintf("Issues linked successfully!\n%s %s %s", source, linkType.Outward, destination)
+	return mcp.NewToolResultText(result), nil
+}
+
+func JiraDeleteIssueLinkHandler(ctx context.Context, request mcp.CallToolRequest, input DeleteIssueLinkInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	if input.LinkID != "" {
+		if _, err := client.Issue.DeleteLinkWithContext(ctx, input.LinkID); err != nil {
+			return nil, fmt.Errorf("failed to delete issue link: %v", err)
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Issue link %s deleted successfully!", input.LinkID)), nil
+	}
+
+	if input.IssueKey == "" || input.TargetIssueKey == "" {
+		return nil, fmt.Errorf("either link_id or both issue_key and target_issue_key are required")
+	}
+
+	issue, _, err := client.Issue.GetWithContext(ctx, input.IssueKey, &jira.GetQueryOptions{Fields: "issuelinks"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %v", err)
+	}
+
+	var deleted []string
+	for _, link := range issue.Fields.IssueLinks {
+		other, relation := linkEnd(link)
+		if other == nil || !strings.EqualFold(other.Key, input.TargetIssueKey) {
+			continue
+		}
+		if input.LinkType != "" && !linkTypeMatches(link.Type, input.LinkType) {
+			continue
+		}
+
+		if _, err := client.Issue.DeleteLinkWithContext(ctx, link.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete issue link %s: %v", link.ID, err)
+		}
+		deleted = append(deleted, fmt.Sprintf("- %s %s %s (ID: %s)", input.IssueKey, relation, other.Key, link.ID))
+	}
+
+	if len(deleted) == 0 {
+		return nil, fmt.Errorf("no link found between %s and %s", input.IssueKey, input.TargetIssueKey)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Deleted %d issue links:\n%s", len(deleted), strings.Join(deleted, "\n"))), nil
+}
+
+func JiraListLinkTypesHandler(ctx context.Context, request mcp.CallToolRequest, input ListLinkTypesInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	linkTypes, _, err := client.IssueLinkType.GetListWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue link types: %v", err)
+	}
+
+	if len(linkTypes) == 0 {
+		return mcp.NewToolResultText("No issue link types found."), nil
+	}
+
+	return mcp.NewToolResultText("Available Issue Link Types:\n\n" + formatLinkTypes(linkTypes)), nil
+}
+
+func JiraGetRelatedIssuesHandler(ctx context.Context, request mcp.CallToolRequest, input GetRelatedIssuesInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	depth := input.Depth
+	if depth <= 0 {
+		depth = 1
+	}
+	if depth > maxRelatedIssuesDepth {
+		depth = maxRelatedIssuesDepth
+	}
+
+	root, _, err := client.Issue.GetWithContext(ctx, input.IssueKey, &jira.GetQueryOptions{Fields: "summary,status,issuelinks"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %v", err)
+	}
+
+	// Breadth-first walk so every issue is reported at its shortest distance from the root
+	visited := map[string]bool{root.Key: true}
+	children := make(map[string][]relatedIssue)
+	queue := []*jira.Issue{root}
+	level := map[string]int{root.Key: 0}
+	found := 0
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if level[current.Key] >= depth || current.Fields == nil {
+			continue
+		}
+
+		for _, link := range current.Fields.IssueLinks {
+			other, relation := linkEnd(link)
+			if other == nil || visited[other.Key] {
+				continue
+			}
+			if input.LinkType != "" && !linkTypeMatches(link.Type, input.LinkType) {
+				continue
+			}
+			visited[other.Key] = true
+
+			related := relatedIssue{
+				Key:      other.Key,
+				Relation: relation,
+				Parent:   current.Key,
+				Depth:    level[current.Key] + 1,
+			}
+			if other.Fields != nil {
+				related.Summary = other.Fields.Summary
+				if other.Fields.Status != nil {
+					related.Status = other.Fields.Status.Name
+				}
+			}
+			children[current.Key] = append(children[current.Key], related)
+			level[other.Key] = related.Depth
+			found++
+
+			if related.Depth < depth {
+				next, _, err := client.Issue.GetWithContext(ctx, other.Key, &jira.GetQueryOptions{Fields: "summary,status,issuelinks"})
+				if err != nil {
+					return nil, fmt.Errorf("failed to get issue %s: %v", other.Key, err)
+				}
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	if found == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No related issues found for %s.", root.Key)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s: %s", root.Key, root.Fields.Summary))
+	if root.Fields.Status != nil {
+		sb.WriteString(fmt.Sprintf(" [%s]", root.Fields.Status.Name))
+	}
+	sb.WriteString(fmt.Sprintf("\nRelated issues (depth %d, %d found):\n", depth, found))
+	writeRelatedIssues(&sb, children, root.Key, 0)
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// writeRelatedIssues renders the link tree below key, indenting each level
+func writeRelatedIssues(sb *strings.Builder, children map[string][]relatedIssue, key string, indent int) {
+	for _, related := range children[key] {
+		sb.WriteString(fmt.Sprintf("%s- %s %s: %s", strings.Repeat("  ", indent), related.Relation, related.Key, related.Summary))
+		if related.Status != "" {
+			sb.WriteString(fmt.Sprintf(" [%s]", related.Status))
+		}
+		sb.WriteString("\n")
+		writeRelatedIssues(sb, children, related.Key, indent+1)
+	}
+}
+
+// linkEnd returns the issue on the other end of a link, as seen from the issue that owns it,
+// together with the relation phrase that reads from the owning issue to that other issue
+func linkEnd(link *jira.IssueLink) (*jira.Issue, string) {
+	if link == nil {
+		return nil, ""
+	}
+	if link.OutwardIssue != nil {
+		return link.OutwardIssue, link.Type.Outward
+	}
+	if link.InwardIssue != nil {
+		return link.InwardIssue, link.Type.Inward
+	}
+	return nil, ""
+}
+
+// matchLinkType finds a link type by name or direction phrase. reversed reports that the
+// phrase matched the inward description, meaning the issues must be swapped
+func matchLinkType(linkTypes []jira.IssueLinkType, value string) (*jira.IssueLinkType, bool) {
+	value = strings.TrimSpace(value)
+	for index := range linkTypes {
+		linkType := &linkTypes[index]
+		if strings.EqualFold(linkType.Name, value) || strings.EqualFold(linkType.Outward, value) {
+			return linkType, false
+		}
+	}
+	for index := range linkTypes {
+		linkType := &linkTypes[index]
+		if strings.EqualFold(linkType.Inward, value) {
+			return linkType, true
+		}
+	}
+	return nil, false
+}
+
+// linkTypeMatches checks a link type against a name or either direction phrase
+func linkTypeMatches(linkType jira.IssueLinkType, value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.EqualFold(linkType.Name, value) ||
+		strings.EqualFold(linkType.Outward, value) ||
+		strings.EqualFold(linkType.Inward, value)
+}
+
+func formatLinkTypes(linkTypes []jira.IssueLinkType) string {
+	var sb strings.Builder
+	for _, linkType := range linkTypes {
+		sb.WriteString(fmt.Sprintf("- %s (ID: %s): outward '%s', inward '%s'\n", linkType.Name, linkType.ID, linkType.Outward, linkType.Inward))
+	}
+	return sb.String()
+}
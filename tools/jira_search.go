@@ -2,7 +2,10 @@ package tools
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"hash/crc32"
 	"strings"
 
 	"github.com/andygrunwald/go-jira"
@@ -14,17 +17,34 @@ import (
 
 // Input types for typed tools
 type SearchIssueInput struct {
-	JQL    string `json:"jql" validate:"required"`
-	Fields string `json:"fields,omitempty"`
-	Expand string `json:"expand,omitempty"`
+	JQL        string `json:"jql" validate:"required"`
+	Fields     string `json:"fields,omitempty"`
+	Expand     string `json:"expand,omitempty"`
+	StartAt    int    `json:"start_at,omitempty"`
+	MaxResults int    `json:"max_results,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+	Format     string `json:"format,omitempty"`
+	Validate   bool   `json:"validate,omitempty"`
 }
 
+const (
+	// defaultSearchResults is the page size of search_issue
+	defaultSearchResults = 30
+	// maxSearchResults is the largest page Jira returns for a search
+	maxSearchResults = 100
+)
+
 func RegisterJiraSearchTool(s *server.MCPServer) {
 	jiraSearchTool := mcp.NewTool("search_issue",
 		mcp.WithDescription("Search for Jira issues using JQL (Jira Query Language). Returns key details like summary, status, assignee, and priority for matching issues"),
 		mcp.WithString("jql", mcp.Required(), mcp.Description("JQL query string (e.g., 'project = KP AND status = \"In Progress\"')")),
-		mcp.WithString("fields", mcp.Description("Comma-separated list of fields to retrieve (e.g., 'summary,status,assignee'). If not specified, all fields are returned.")),
+		mcp.WithString("fields", mcp.Description("Comma-separated list of fields to retrieve by ID or display name (e.g., 'summary,status,assignee,Story Points'). If not specified, all fields are returned.")),
 		mcp.WithString("expand", mcp.Description("Comma-separated list of fields to expand for additional details (e.g., 'transitions,changelog,subtasks,description').")),
+		mcp.WithNumber("start_at", mcp.Description("Index of the first result to return, starting at 0 (default: 0)")),
+		mcp.WithNumber("max_results", mcp.Description(fmt.Sprintf("Number of results per page (default: %d, max: %d)", defaultSearchResults, maxSearchResults))),
+		mcp.WithString("cursor", mcp.Description("Cursor from a previous search_issue result to fetch the next page of the same query; overrides start_at")),
+		mcp.WithString("format", mcp.Description("Output format: 'full' (every detail, default), 'compact' (one line per issue), 'table' (Markdown table of the requested fields), 'json' or 'csv' (requested fields for downstream processing)")),
+		mcp.WithBoolean("validate", mcp.Description("Validate the query with Jira's JQL parse endpoint before the first page is fetched, reporting every problem with suggested fixes (default: false). Without it, the query is only validated after Jira rejects the search")),
 	)
 	s.AddTool(jiraSearchTool, mcp.NewTypedToolHandler(JiraSearchHandler))
 }
@@ -32,44 +52,260 @@ func RegisterJiraSearchTool(s *server.MCPServer) {
 func JiraSearchHandler(ctx context.Context, request mcp.CallToolRequest, input SearchIssueInput) (*mcp.CallToolResult, error) {
 	client := services.JiraClient()
 
-	// Parse expand parameter
-	expand := "transitions,changelog,subtasks"
+	format := strings.ToLower(strings.TrimSpace(input.Format))
+	if format == "" {
+		format = "full"
+	}
+	if format != "full" && format != "compact" && format != "table" && format != "json" && format != "csv" {
+		return nil, fmt.Errorf("invalid format %q. Valid formats: full, compact, table, json, csv", input.Format)
+	}
+
+	// Parse expand parameter; only the full format shows transitions, changelog and subtasks
+	expand := ""
+	if format == "full" {
+		expand = "transitions,changelog,subtasks"
+	}
 	if input.Expand != "" {
 		expand = input.Expand
 	}
 
+	maxResults := input.MaxResults
+	if maxResults <= 0 {
+		maxResults = defaultSearchResults
+	}
+	if maxResults > maxSearchResults {
+		maxResults = maxSearchResults
+	}
+
+	startAt := input.StartAt
+	if input.Cursor != "" {
+		var err error
+		if startAt, err = decodeSearchCursor(input.Cursor, input.JQL); err != nil {
+			return nil, err
+		}
+	}
+	if startAt < 0 {
+		startAt = 0
+	}
+
 	// Prepare search options
 	searchOptions := &jira.SearchOptions{
-		StartAt:    0,
-		MaxResults: 30,
+		StartAt:    startAt,
+		MaxResults: maxResults,
 		Expand:     expand,
 		Fields:     []string{},
 	}
 
-	// Parse fields parameter
+	// Parse fields parameter, resolving custom field names such as "Story Points" to their IDs
 	if input.Fields != "" {
-		fields := strings.Split(strings.ReplaceAll(input.Fields, " ", ""), ",")
+		fields, err := resolveSearchFields(ctx, client, input.Fields)
+		if err != nil {
+			return nil, err
+		}
 		searchOptions.Fields = fields
 	}
 
-	issues, _, err := client.Issue.SearchWithContext(ctx, input.JQL, searchOptions)
+	// The other formats show a fixed set of fields, so only those are fetched.
+	// Compact lines always show summary, status, assignee and priority, so they are added to requested fields
+	switch {
+	case format == "compact":
+		for _, field := range []string{"summary", "status", "assignee", "priority"} {
+			if !containsFold(searchOptions.Fields, field) {
+				searchOptions.Fields = append(searchOptions.Fields, field)
+			}
+		}
+	case format != "full" && len(searchOptions.Fields) == 0:
+		searchOptions.Fields = util.DefaultIssueColumns
+	}
+
+	// The validation pre-pass reports every problem with suggestions before searching. Later pages reuse a
+	// query that already worked, so they skip it. Where the parse endpoint is unavailable the search reports problems
+	if input.Validate && startAt == 0 {
+		if problems, supported, err := parseJQL(ctx, client, input.JQL); err == nil && supported && len(problems) > 0 {
+			return nil, fmt.Errorf("invalid JQL query:\n%s", formatJQLProblems(ctx, client, problems))
+		}
+	}
+
+	issues, response, err := client.Issue.SearchWithContext(ctx, input.JQL, searchOptions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search issues: %v", err)
+		return nil, searchFailure(ctx, client, input.JQL, err)
 	}
 
 	if len(issues) == 0 {
+		if startAt > 0 && response.Total > 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No more issues: the query matches %d issues and start_at is %d.", response.Total, startAt)), nil
+		}
 		return mcp.NewToolResultText("No issues found matching the search criteria."), nil
 	}
 
+	// Load the field registry so custom fields are shown by name; IDs are shown if it is unavailable
+	_, _ = util.LoadFields(ctx, client)
+
+	columns := util.NewIssueColumns(searchOptions.Fields)
+	if len(columns) == 0 {
+		columns = util.NewIssueColumns(util.DefaultIssueColumns)
+	}
+
+	// JSON output carries the paging details itself so it stays machine-readable
+	if format == "json" {
+		nextCursor := ""
+		if startAt+len(issues) < response.Total {
+			nextCursor = encodeSearchCursor(input.JQL, startAt+len(issues))
+		}
+		result, err := util.FormatJSON(map[string]interface{}{
+			"total":       response.Total,
+			"start_at":    startAt,
+			"next_cursor": nextCursor,
+			"issues":      util.IssueRecords(issues, columns),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to format issues: %v", err)
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+
+	// The first content item holds only the CSV so it parses as-is; the paging details go in a second item
+	if format == "csv" {
+		result, err := util.FormatIssuesCSV(issues, columns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format issues: %v", err)
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(result),
+				mcp.NewTextContent(strings.TrimSpace(searchPageHeader(input.JQL, startAt, len(issues), response.Total))),
+			},
+		}, nil
+	}
+
 	var sb strings.Builder
-	for index, issue := range issues {
-		// Use the comprehensive formatter for each issue
-		formattedIssue := util.FormatJiraIssue(&issue)
-		sb.WriteString(formattedIssue)
-		if index < len(issues)-1 {
-			sb.WriteString("\n===\n")
+	sb.WriteString(searchPageHeader(input.JQL, startAt, len(issues), response.Total))
+	switch format {
+	case "compact":
+		for index := range issues {
+			sb.WriteString(util.FormatJiraIssueCompact(&issues[index]))
+			sb.WriteString("\n")
+		}
+	case "table":
+		sb.WriteString(util.FormatIssuesTable(issues, columns))
+	default:
+		for index, issue := range issues {
+			// Use the comprehensive formatter for each issue
+			formattedIssue := util.FormatJiraIssueWithFields(&issue, searchOptions.Fields)
+			sb.WriteString(formattedIssue)
+			if index < len(issues)-1 {
+				sb.WriteString("\n===\n")
+			}
 		}
 	}
 
 	return mcp.NewToolResultText(sb.String()), nil
 }
+
+// searchPageHeader tells how many issues match in total and how to fetch the next page
+func searchPageHeader(jql string, startAt int, count int, total int) string {
+	last := startAt + count
+	if last >= total {
+		return fmt.Sprintf("Showing issues %d-%d of %d (all results shown)\n\n", startAt+1, last, total)
+	}
+	return fmt.Sprintf("Showing issues %d-%d of %d. More results available: call search_issue again with cursor %q (or start_at %d)\n\n", startAt+1, last, total, encodeSearchCursor(jql, last), last)
+}
+
+// encodeSearchCursor packs the next start index with a checksum of the query so a cursor is not reused
+// for a different search
+func encodeSearchCursor(jql string, startAt int) string {
+	checksum := crc32.ChecksumIEEE([]byte(strings.TrimSpace(jql)))
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%08x", startAt, checksum)))
+}
+
+func decodeSearchCursor(cursor string, jql string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(cursor))
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	var startAt int
+	var checksum uint32
+	if _, err := fmt.Sscanf(string(decoded), "%d:%08x", &startAt, &checksum); err != nil {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	if checksum != crc32.ChecksumIEEE([]byte(strings.TrimSpace(jql))) {
+		return 0, fmt.Errorf("cursor %q belongs to a different query; pass the same jql or use start_at", cursor)
+	}
+
+	return startAt, nil
+}
+
+// resolveSearchFields splits a comma-separated field list and maps display names to field IDs
+// Entries the registry does not know, such as "*all" or "-comment", are passed through unchanged
+func resolveSearchFields(ctx context.Context, client *jira.Client, value string) ([]string, error) {
+	var fields []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if strings.HasPrefix(name, "*") || strings.HasPrefix(name, "-") {
+			fields = append(fields, name)
+			continue
+		}
+
+		field, err := util.ResolveField(ctx, client, name)
+		if errors.Is(err, util.ErrFieldNotFound) {
+			fields = append(fields, name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field.ID)
+	}
+	return fields, nil
+}
+
+// errSearchLimitReached stops SearchPages once enough issues have been collected
+var errSearchLimitReached = errors.New("search limit reached")
+
+// searchAllIssues runs a JQL search and follows pagination until limit issues are collected.
+// A limit of zero collects every matching issue
+func searchAllIssues(ctx context.Context, client *jira.Client, jql string, options *jira.SearchOptions, limit int) ([]jira.Issue, error) {
+	var issues []jira.Issue
+
+	if options == nil {
+		options = &jira.SearchOptions{}
+	}
+	if options.MaxResults == 0 {
+		options.MaxResults = 100
+	}
+	if limit > 0 && limit < options.MaxResults {
+		options.MaxResults = limit
+	}
+
+	err := client.Issue.SearchPagesWithContext(ctx, jql, options, func(issue jira.Issue) error {
+		issues = append(issues, issue)
+		if limit > 0 && len(issues) >= limit {
+			return errSearchLimitReached
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errSearchLimitReached) {
+		return nil, searchFailure(ctx, client, jql, err)
+	}
+
+	return issues, nil
+}
+
+// searchErrorHint points at the tool that lists valid values when Jira rejects a status in a JQL query.
+// Only Jira's unknown value message counts; every failed request mentions its HTTP status code
+func searchErrorHint(err error) string {
+	message := strings.ToLower(err.Error())
+	if strings.Contains(message, "does not exist for the field 'status'") {
+		return "\nHint: status names differ between projects and workflows. Use list_statuses with the project key to see valid status names, or filter by statusCategory (e.g., statusCategory = \"In Progress\")"
+	}
+	return ""
+}
+
+// quoteJQL wraps a value in double quotes for use in a JQL clause, escaping embedded quotes
+func quoteJQL(value string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), `"`, `\"`) + `"`
+}
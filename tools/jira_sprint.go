@@ -0,0 +1,739 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type ListSprintsInput struct {
+	BoardID    string `json:"board_id,omitempty"`
+	ProjectKey string `json:"project_key,omitempty"`
+	State      string `json:"state,omitempty"`
+}
+
+type GetSprintInput struct {
+	SprintID string `json:"sprint_id" validate:"required"`
+}
+
+type GetActiveSprintInput struct {
+	BoardID    string `json:"board_id,omitempty"`
+	ProjectKey string `json:"project_key,omitempty"`
+}
+
+type MoveIssuesToSprintInput struct {
+	SprintID  string `json:"sprint_id" validate:"required"`
+	IssueKeys string `json:"issue_keys" validate:"required"`
+}
+
+type MoveIssuesToBacklogInput struct {
+	IssueKeys string `json:"issue_keys" validate:"required"`
+}
+
+type CreateSprintInput struct {
+	BoardID    string `json:"board_id,omitempty"`
+	ProjectKey string `json:"project_key,omitempty"`
+	Name       string `json:"name" validate:"required"`
+	Goal       string `json:"goal,omitempty"`
+	StartDate  string `json:"start_date,omitempty"`
+	EndDate    string `json:"end_date,omitempty"`
+}
+
+type UpdateSprintInput struct {
+	SprintID  string `json:"sprint_id" validate:"required"`
+	Name      string `json:"name,omitempty"`
+	Goal      string `json:"goal,omitempty"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+type StartSprintInput struct {
+	SprintID  string `json:"sprint_id" validate:"required"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+	Goal      string `json:"goal,omitempty"`
+}
+
+type CompleteSprintInput struct {
+	SprintID         string `json:"sprint_id" validate:"required"`
+	MoveIncompleteTo string `json:"move_incomplete_to,omitempty"`
+}
+
+// maxIssuesPerMove is the Agile API limit for issues moved in a single request
+const maxIssuesPerMove = 50
+
+// issueMoveResult records the outcome of moving a single issue
+type issueMoveResult struct {
+	IssueKey string
+	Err      error
+}
+
+// sprintIssuesPage is a single page of the sprint issue listing
+type sprintIssuesPage struct {
+	StartAt    int          `json:"startAt"`
+	MaxResults int          `json:"maxResults"`
+	Total      int          `json:"total"`
+	Issues     []jira.Issue `json:"issues"`
+}
+
+// sprintPage is a single page of the board sprint listing
+type sprintPage struct {
+	MaxResults int           `json:"maxResults"`
+	StartAt    int           `json:"startAt"`
+	IsLast     bool          `json:"isLast"`
+	Values     []util.Sprint `json:"values"`
+}
+
+func RegisterJiraSprintTool(s *server.MCPServer) {
+	jiraListSprintsTool := mcp.NewTool("list_sprints",
+		mcp.WithDescription("List sprints of a Jira agile board with their state, goal, and dates. Provide either board_id or project_key"),
+		mcp.WithString("board_id", mcp.Description("Numeric ID of the agile board (e.g., 42)")),
+		mcp.WithString("project_key", mcp.Description("Project key used to look up the board when board_id is not known (e.g., KP)")),
+		mcp.WithString("state", mcp.Description("Comma-separated sprint states to include: future, active, closed. Defaults to all states")),
+	)
+	s.AddTool(jiraListSprintsTool, mcp.NewTypedToolHandler(JiraListSprintsHandler))
+
+	jiraGetSprintTool := mcp.NewTool("get_sprint",
+		mcp.WithDescription("Retrieve details of a specific sprint including its goal, state, and start/end dates"),
+		mcp.WithString("sprint_id", mcp.Required(), mcp.Description("Numeric ID of the sprint (e.g., 123)")),
+	)
+	s.AddTool(jiraGetSprintTool, mcp.NewTypedToolHandler(JiraGetSprintHandler))
+
+	jiraGetActiveSprintTool := mcp.NewTool("get_active_sprint",
+		mcp.WithDescription("Retrieve the currently active sprint of a Jira agile board. Provide either board_id or project_key"),
+		mcp.WithString("board_id", mcp.Description("Numeric ID of the agile board (e.g., 42)")),
+		mcp.WithString("project_key", mcp.Description("Project key used to look up the board when board_id is not known (e.g., KP)")),
+	)
+	s.AddTool(jiraGetActiveSprintTool, mcp.NewTypedToolHandler(JiraGetActiveSprintHandler))
+
+	jiraMoveIssuesToSprintTool := mcp.NewTool("move_issues_to_sprint",
+		mcp.WithDescription("Move one or more issues into an open or active sprint. Reports success or failure for each issue key"),
+		mcp.WithString("sprint_id", mcp.Required(), mcp.Description("Numeric ID of the target sprint (e.g., 123)")),
+		mcp.WithString("issue_keys", mcp.Required(), mcp.Description("Comma-separated list of issue keys to move (e.g., 'KP-1,KP-2,KP-3')")),
+	)
+	s.AddTool(jiraMoveIssuesToSprintTool, mcp.NewTypedToolHandler(JiraMoveIssuesToSprintHandler))
+
+	jiraMoveIssuesToBacklogTool := mcp.NewTool("move_issues_to_backlog",
+		mcp.WithDescription("Move one or more issues out of their sprint and back to the backlog. Reports success or failure for each issue key"),
+		mcp.WithString("issue_keys", mcp.Required(), mcp.Description("Comma-separated list of issue keys to move (e.g., 'KP-1,KP-2,KP-3')")),
+	)
+	s.AddTool(jiraMoveIssuesToBacklogTool, mcp.NewTypedToolHandler(JiraMoveIssuesToBacklogHandler))
+
+	jiraCreateSprintTool := mcp.NewTool("create_sprint",
+		mcp.WithDescription("Create a new future sprint on a Jira agile board. Provide either board_id or project_key"),
+		mcp.WithString("board_id", mcp.Description("Numeric ID of the agile board (e.g., 42)")),
+		mcp.WithString("project_key", mcp.Description("Project key used to look up the board when board_id is not known (e.g., KP)")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the sprint (e.g., 'KP Sprint 12')")),
+		mcp.WithString("goal", mcp.Description("Sprint goal (optional)")),
+		mcp.WithString("start_date", mcp.Description("Planned start date (e.g., '2024-01-15' or '2024-01-15T09:00:00+01:00')")),
+		mcp.WithString("end_date", mcp.Description("Planned end date (e.g., '2024-01-29' or '2024-01-29T17:00:00+01:00')")),
+	)
+	s.AddTool(jiraCreateSprintTool, mcp.NewTypedToolHandler(JiraCreateSprintHandler))
+
+	jiraUpdateSprintTool := mcp.NewTool("update_sprint",
+		mcp.WithDescription("Edit a sprint's name, goal, or dates. Only specified fields will be changed"),
+		mcp.WithString("sprint_id", mcp.Required(), mcp.Description("Numeric ID of the sprint (e.g., 123)")),
+		mcp.WithString("name", mcp.Description("New name for the sprint (optional)")),
+		mcp.WithString("goal", mcp.Description("New sprint goal (optional)")),
+		mcp.WithString("start_date", mcp.Description("New start date (e.g., '2024-01-15' or '2024-01-15T09:00:00+01:00')")),
+		mcp.WithString("end_date", mcp.Description("New end date (e.g., '2024-01-29' or '2024-01-29T17:00:00+01:00')")),
+	)
+	s.AddTool(jiraUpdateSprintTool, mcp.NewTypedToolHandler(JiraUpdateSprintHandler))
+
+	jiraStartSprintTool := mcp.NewTool("start_sprint",
+		mcp.WithDescription("Start a future sprint, making it the active sprint of its board"),
+		mcp.WithString("sprint_id", mcp.Required(), mcp.Description("Numeric ID of the sprint to start (e.g., 123)")),
+		mcp.WithString("start_date", mcp.Description("Start date (defaults to the planned start date, or now)")),
+		mcp.WithString("end_date", mcp.Description("End date (defaults to the planned end date; required if the sprint has none)")),
+		mcp.WithString("goal", mcp.Description("Sprint goal to set when starting (optional)")),
+	)
+	s.AddTool(jiraStartSprintTool, mcp.NewTypedToolHandler(JiraStartSprintHandler))
+
+	jiraCompleteSprintTool := mcp.NewTool("complete_sprint",
+		mcp.WithDescription("Complete an active sprint. Unfinished issues are moved to the next future sprint of the board, to the backlog, or to a given sprint"),
+		mcp.WithString("sprint_id", mcp.Required(), mcp.Description("Numeric ID of the active sprint to complete (e.g., 123)")),
+		mcp.WithString("move_incomplete_to", mcp.Description("Where to move unfinished issues: 'next' (default, falls back to the backlog when no future sprint exists), 'backlog', or a numeric sprint ID")),
+	)
+	s.AddTool(jiraCompleteSprintTool, mcp.NewTypedToolHandler(JiraCompleteSprintHandler))
+}
+
+func JiraListSprintsHandler(ctx context.Context, request mcp.CallToolRequest, input ListSprintsInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	boardID, err := resolveBoardID(ctx, client, input.BoardID, input.ProjectKey, "scrum")
+	if err != nil {
+		return nil, err
+	}
+
+	state := strings.ReplaceAll(input.State, " ", "")
+	sprints, err := listBoardSprints(ctx, client, boardID, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sprints) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No sprints found for board %d.", boardID)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Sprints for board %d:\n\n", boardID))
+	for index, sprint := range sprints {
+		sb.WriteString(util.FormatSprint(&sprint))
+		if index < len(sprints)-1 {
+			sb.WriteString("\n")
+		}
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func JiraGetSprintHandler(ctx context.Context, request mcp.CallToolRequest, input GetSprintInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	sprintID, err := parseSprintID(input.SprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	sprint, err := getSprint(ctx, client, sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(util.FormatSprint(sprint)), nil
+}
+
+func JiraGetActiveSprintHandler(ctx context.Context, request mcp.CallToolRequest, input GetActiveSprintInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	boardID, err := resolveBoardID(ctx, client, input.BoardID, input.ProjectKey, "scrum")
+	if err != nil {
+		return nil, err
+	}
+
+	sprints, err := listBoardSprints(ctx, client, boardID, "active")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sprints) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No active sprint found for board %d.", boardID)), nil
+	}
+
+	// Boards with parallel sprints enabled can have more than one active sprint
+	var sb strings.Builder
+	for index, sprint := range sprints {
+		sb.WriteString(util.FormatSprint(&sprint))
+		if index < len(sprints)-1 {
+			sb.WriteString("\n")
+		}
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func JiraMoveIssuesToSprintHandler(ctx context.Context, request mcp.CallToolRequest, input MoveIssuesToSprintInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	sprintID, err := parseSprintID(input.SprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	issueKeys := parseIssueKeys(input.IssueKeys)
+	if len(issueKeys) == 0 {
+		return nil, fmt.Errorf("issue_keys must contain at least one issue key")
+	}
+
+	results := moveIssues(ctx, client, fmt.Sprintf("rest/agile/1.0/sprint/%d/issue", sprintID), issueKeys)
+
+	return mcp.NewToolResultText(formatMoveResults(fmt.Sprintf("sprint %d", sprintID), results)), nil
+}
+
+func JiraMoveIssuesToBacklogHandler(ctx context.Context, request mcp.CallToolRequest, input MoveIssuesToBacklogInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	issueKeys := parseIssueKeys(input.IssueKeys)
+	if len(issueKeys) == 0 {
+		return nil, fmt.Errorf("issue_keys must contain at least one issue key")
+	}
+
+	results := moveIssues(ctx, client, "rest/agile/1.0/backlog/issue", issueKeys)
+
+	return mcp.NewToolResultText(formatMoveResults("the backlog", results)), nil
+}
+
+func JiraCreateSprintHandler(ctx context.Context, request mcp.CallToolRequest, input CreateSprintInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	boardID, err := resolveBoardID(ctx, client, input.BoardID, input.ProjectKey, "scrum")
+	if err != nil {
+		return nil, err
+	}
+
+	sprintData := map[string]interface{}{
+		"name":          input.Name,
+		"originBoardId": boardID,
+	}
+
+	if input.Goal != "" {
+		sprintData["goal"] = input.Goal
+	}
+
+	if err := setSprintDates(sprintData, input.StartDate, input.EndDate); err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewRequestWithContext(ctx, "POST", "rest/agile/1.0/sprint", sprintData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	sprint := new(util.Sprint)
+	response, err := client.Do(req, sprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sprint: %v", jira.NewJiraError(response, err))
+	}
+
+	return mcp.NewToolResultText("Sprint created successfully!\n" + util.FormatSprint(sprint)), nil
+}
+
+func JiraUpdateSprintHandler(ctx context.Context, request mcp.CallToolRequest, input UpdateSprintInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	sprintID, err := parseSprintID(input.SprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	sprintData := map[string]interface{}{}
+
+	if input.Name != "" {
+		sprintData["name"] = input.Name
+	}
+
+	if input.Goal != "" {
+		sprintData["goal"] = input.Goal
+	}
+
+	if err := setSprintDates(sprintData, input.StartDate, input.EndDate); err != nil {
+		return nil, err
+	}
+
+	if len(sprintData) == 0 {
+		return nil, fmt.Errorf("nothing to update: provide at least one of name, goal, start_date or end_date")
+	}
+
+	sprint, err := updateSprint(ctx, client, sprintID, sprintData)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText("Sprint updated successfully!\n" + util.FormatSprint(sprint)), nil
+}
+
+func JiraStartSprintHandler(ctx context.Context, request mcp.CallToolRequest, input StartSprintInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	sprintID, err := parseSprintID(input.SprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	sprint, err := getSprint(ctx, client, sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sprint.State != "future" {
+		return nil, fmt.Errorf("sprint %d cannot be started because it is %s", sprintID, sprint.State)
+	}
+
+	// Starting a sprint requires both dates, so fall back to the planned ones
+	startDate := input.StartDate
+	if startDate == "" {
+		if sprint.StartDate != nil && !sprint.StartDate.IsZero() {
+			startDate = sprint.StartDate.Format(time.RFC3339)
+		} else {
+			startDate = time.Now().Format(time.RFC3339)
+		}
+	}
+
+	endDate := input.EndDate
+	if endDate == "" {
+		if sprint.EndDate == nil || sprint.EndDate.IsZero() {
+			return nil, fmt.Errorf("sprint %d has no planned end date: end_date is required", sprintID)
+		}
+		endDate = sprint.EndDate.Format(time.RFC3339)
+	}
+
+	sprintData := map[string]interface{}{
+		"state": "active",
+	}
+
+	if input.Goal != "" {
+		sprintData["goal"] = input.Goal
+	}
+
+	if err := setSprintDates(sprintData, startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	sprint, err = updateSprint(ctx, client, sprintID, sprintData)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText("Sprint started successfully!\n" + util.FormatSprint(sprint)), nil
+}
+
+func JiraCompleteSprintHandler(ctx context.Context, request mcp.CallToolRequest, input CompleteSprintInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	sprintID, err := parseSprintID(input.SprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	sprint, err := getSprint(ctx, client, sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sprint.State != "active" {
+		return nil, fmt.Errorf("sprint %d cannot be completed because it is %s", sprintID, sprint.State)
+	}
+
+	incompleteIssues, err := listSprintIssues(ctx, client, sprintID, "statusCategory != Done", "summary,status,issuetype")
+	if err != nil {
+		return nil, err
+	}
+
+	// Sub-tasks follow their parent and cannot be moved on their own
+	issueKeys := make([]string, 0, len(incompleteIssues))
+	subtasks := 0
+	for _, issue := range incompleteIssues {
+		if issue.Fields != nil && issue.Fields.Type.Subtask {
+			subtasks++
+			continue
+		}
+		issueKeys = append(issueKeys, issue.Key)
+	}
+
+	// The destination is checked before anything changes, so an invalid target leaves the sprint untouched
+	destination, endpoint := "", ""
+	if len(issueKeys) > 0 {
+		if destination, endpoint, err = resolveIncompleteDestination(ctx, client, sprint, input.MoveIncompleteTo); err != nil {
+			return nil, err
+		}
+	}
+
+	// Close the sprint before moving its unfinished issues, so Jira records them as not completed
+	// rather than removed from the sprint
+	sprint, err = updateSprint(ctx, client, sprintID, map[string]interface{}{
+		"state": "closed",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+
+	if len(issueKeys) > 0 {
+		results := moveIssues(ctx, client, endpoint, issueKeys)
+		sb.WriteString(formatMoveResults(destination, results))
+		sb.WriteString("\n")
+		for _, result := range results {
+			if result.Err != nil {
+				sb.WriteString("Some unfinished issues could not be moved; they remain in the closed sprint. Retry with move_issues_to_sprint or move_issues_to_backlog.\n\n")
+				break
+			}
+		}
+	} else if subtasks == 0 {
+		sb.WriteString("All issues in the sprint are done.\n\n")
+	}
+
+	if subtasks > 0 {
+		sb.WriteString(fmt.Sprintf("%d unfinished sub-task(s) were not moved; sub-tasks follow their parent issues.\n\n", subtasks))
+	}
+
+	sb.WriteString("Sprint completed successfully!\n")
+	sb.WriteString(util.FormatSprint(sprint))
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// parseSprintID converts the sprint_id tool argument to the numeric ID used by the Agile API
+func parseSprintID(value string) (int, error) {
+	sprintID, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid sprint_id %q: must be a number", value)
+	}
+	return sprintID, nil
+}
+
+// getSprint fetches a single sprint from the Agile API
+func getSprint(ctx context.Context, client *jira.Client, sprintID int) (*util.Sprint, error) {
+	req, err := client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("rest/agile/1.0/sprint/%d", sprintID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	sprint := new(util.Sprint)
+	response, err := client.Do(req, sprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sprint: %v", jira.NewJiraError(response, err))
+	}
+
+	return sprint, nil
+}
+
+// listBoardSprints fetches every sprint of a board, following the Agile API pagination.
+// state is an optional comma-separated list of sprint states (future, active, closed)
+func listBoardSprints(ctx context.Context, client *jira.Client, boardID int, state string) ([]util.Sprint, error) {
+	var sprints []util.Sprint
+
+	startAt := 0
+	for {
+		query := url.Values{}
+		query.Set("startAt", strconv.Itoa(startAt))
+		if state != "" {
+			query.Set("state", state)
+		}
+
+		req, err := client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("rest/agile/1.0/board/%d/sprint?%s", boardID, query.Encode()), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+
+		var page sprintPage
+		response, err := client.Do(req, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sprints: %v", jira.NewJiraError(response, err))
+		}
+
+		sprints = append(sprints, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return sprints, nil
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// parseIssueKeys splits a comma or whitespace separated list of issue keys, dropping duplicates
+func parseIssueKeys(value string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+
+	for _, key := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	}) {
+		key = strings.ToUpper(key)
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// moveIssues posts issue keys to an Agile API move endpoint (sprint or backlog) in batches.
+// The endpoint rejects a whole batch when any key is invalid, so failed batches are retried
+// one issue at a time to attribute the failure to the offending keys
+func moveIssues(ctx context.Context, client *jira.Client, endpoint string, issueKeys []string) []issueMoveResult {
+	var results []issueMoveResult
+
+	for start := 0; start < len(issueKeys); start += maxIssuesPerMove {
+		end := min(start+maxIssuesPerMove, len(issueKeys))
+		batch := issueKeys[start:end]
+
+		if err := postIssueMove(ctx, client, endpoint, batch); err == nil || len(batch) == 1 {
+			for _, key := range batch {
+				results = append(results, issueMoveResult{IssueKey: key, Err: err})
+			}
+			continue
+		}
+
+		for _, key := range batch {
+			err := postIssueMove(ctx, client, endpoint, []string{key})
+			results = append(results, issueMoveResult{IssueKey: key, Err: err})
+		}
+	}
+
+	return results
+}
+
+func postIssueMove(ctx context.Context, client *jira.Client, endpoint string, issueKeys []string) error {
+	req, err := client.NewRequestWithContext(ctx, "POST", endpoint, jira.IssuesWrapper{Issues: issueKeys})
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	response, err := client.Do(req, nil)
+	if err != nil {
+		return jira.NewJiraError(response, err)
+	}
+	response.Body.Close()
+
+	return nil
+}
+
+// formatMoveResults renders a per-issue success/failure report for a move operation
+func formatMoveResults(destination string, results []issueMoveResult) string {
+	var succeeded, failed []issueMoveResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		} else {
+			succeeded = append(succeeded, result)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Moved %d of %d issues to %s.\n", len(succeeded), len(results), destination))
+
+	if len(succeeded) > 0 {
+		sb.WriteString("\nSucceeded:\n")
+		for _, result := range succeeded {
+			sb.WriteString(fmt.Sprintf("- %s\n", result.IssueKey))
+		}
+	}
+
+	if len(failed) > 0 {
+		sb.WriteString("\nFailed:\n")
+		for _, result := range failed {
+			sb.WriteString(fmt.Sprintf("- %s: %v\n", result.IssueKey, result.Err))
+		}
+	}
+
+	return sb.String()
+}
+
+// setSprintDates parses optional start and end dates into an Agile API sprint payload
+func setSprintDates(sprintData map[string]interface{}, startDate string, endDate string) error {
+	var start, end time.Time
+	var err error
+
+	if startDate != "" {
+		if start, err = util.ParseDateTime(startDate); err != nil {
+			return fmt.Errorf("invalid start_date: %v", err)
+		}
+		sprintData["startDate"] = start.Format(time.RFC3339)
+	}
+
+	if endDate != "" {
+		if end, err = util.ParseDateTime(endDate); err != nil {
+			return fmt.Errorf("invalid end_date: %v", err)
+		}
+		sprintData["endDate"] = end.Format(time.RFC3339)
+	}
+
+	if !start.IsZero() && !end.IsZero() && !end.After(start) {
+		return fmt.Errorf("end_date must be after start_date")
+	}
+
+	return nil
+}
+
+// updateSprint applies a partial update to a sprint and returns the updated sprint
+func updateSprint(ctx context.Context, client *jira.Client, sprintID int, sprintData map[string]interface{}) (*util.Sprint, error) {
+	req, err := client.NewRequestWithContext(ctx, "POST", fmt.Sprintf("rest/agile/1.0/sprint/%d", sprintID), sprintData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	sprint := new(util.Sprint)
+	response, err := client.Do(req, sprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sprint: %v", jira.NewJiraError(response, err))
+	}
+
+	return sprint, nil
+}
+
+// listSprintIssues fetches every issue of a sprint, optionally narrowed by a JQL clause
+func listSprintIssues(ctx context.Context, client *jira.Client, sprintID int, jql string, fields string) ([]jira.Issue, error) {
+	var issues []jira.Issue
+
+	startAt := 0
+	for {
+		query := url.Values{}
+		query.Set("startAt", strconv.Itoa(startAt))
+		if jql != "" {
+			query.Set("jql", jql)
+		}
+		if fields != "" {
+			query.Set("fields", fields)
+		}
+
+		req, err := client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("rest/agile/1.0/sprint/%d/issue?%s", sprintID, query.Encode()), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+
+		var page sprintIssuesPage
+		response, err := client.Do(req, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sprint issues: %v", jira.NewJiraError(response, err))
+		}
+
+		issues = append(issues, page.Issues...)
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			return issues, nil
+		}
+	}
+}
+
+// resolveIncompleteDestination maps the move_incomplete_to argument of complete_sprint to
+// a human readable destination and the Agile API endpoint that moves issues there
+func resolveIncompleteDestination(ctx context.Context, client *jira.Client, sprint *util.Sprint, moveTo string) (string, string, error) {
+	moveTo = strings.ToLower(strings.TrimSpace(moveTo))
+
+	switch moveTo {
+	case "backlog":
+		return "the backlog", "rest/agile/1.0/backlog/issue", nil
+	case "", "next":
+		if sprint.OriginBoardID != 0 {
+			futureSprints, err := listBoardSprints(ctx, client, sprint.OriginBoardID, "future")
+			if err != nil {
+				return "", "", err
+			}
+			if len(futureSprints) > 0 {
+				next := futureSprints[0]
+				return fmt.Sprintf("sprint %d (%s)", next.ID, next.Name), fmt.Sprintf("rest/agile/1.0/sprint/%d/issue", next.ID), nil
+			}
+		}
+		return "the backlog (no future sprint exists)", "rest/agile/1.0/backlog/issue", nil
+	default:
+		targetID, err := strconv.Atoi(moveTo)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid move_incomplete_to %q: use 'next', 'backlog' or a numeric sprint ID", moveTo)
+		}
+		if targetID == sprint.ID {
+			return "", "", fmt.Errorf("move_incomplete_to cannot be the sprint being completed")
+		}
+		target, err := getSprint(ctx, client, targetID)
+		if err != nil {
+			return "", "", err
+		}
+		if target.State != "future" && target.State != "active" {
+			return "", "", fmt.Errorf("move_incomplete_to sprint %d is %s; choose a future or active sprint", targetID, target.State)
+		}
+		if sprint.OriginBoardID != 0 && target.OriginBoardID != 0 && target.OriginBoardID != sprint.OriginBoardID {
+			return "", "", fmt.Errorf("move_incomplete_to sprint %d belongs to board %d, not board %d of the sprint being completed", targetID, target.OriginBoardID, sprint.OriginBoardID)
+		}
+		return fmt.Sprintf("sprint %d (%s)", target.ID, target.Name), fmt.Sprintf("rest/agile/1.0/sprint/%d/issue", targetID), nil
+	}
+}
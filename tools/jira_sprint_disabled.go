@@ -1,12 +0,0 @@
-package tools
-
-import (
-	"github.com/mark3labs/mcp-go/server"
-)
-
-// RegisterJiraSprintTool is disabled for now as andygrunwald/go-jira may not support agile operations
-// The sprint functionality will need to be implemented using direct API calls or a different approach
-func RegisterJiraSprintTool(s *server.MCPServer) {
-	// Sprint tools are temporarily disabled during migration
-	// TODO: Implement sprint functionality using direct API calls
-}
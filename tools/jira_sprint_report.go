@@ -0,0 +1,578 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type SprintReportInput struct {
+	Mode        string `json:"mode,omitempty"`
+	SprintID    string `json:"sprint_id,omitempty"`
+	BoardID     string `json:"board_id,omitempty"`
+	ProjectKey  string `json:"project_key,omitempty"`
+	SprintCount int    `json:"sprint_count,omitempty"`
+}
+
+// estimationField identifies the field used to size issues and its changelog name
+type estimationField struct {
+	ID   string
+	Name string
+}
+
+// sprintScopeIssue is an issue classified against a sprint's scope
+type sprintScopeIssue struct {
+	Key        string
+	Summary    string
+	Status     string
+	Committed  bool
+	Added      bool
+	Removed    bool
+	Completed  bool
+	StartValue float64
+	EndValue   float64
+}
+
+// sprintReport holds the scope changes of one sprint
+type sprintReport struct {
+	Sprint     *util.Sprint
+	Estimation *estimationField
+	Issues     []sprintScopeIssue
+	// RemovedNote explains why issues removed from the sprint may be missing; empty when the search was complete
+	RemovedNote string
+	// SkippedHistories counts changelog entries left out because their timestamp could not be read
+	SkippedHistories int
+}
+
+// maxRemovedCandidates caps the board filter search used to find issues removed from a sprint
+const maxRemovedCandidates = 1000
+
+// orderByPattern strips the ORDER BY clause of a filter so it can be combined with other clauses
+var orderByPattern = regexp.MustCompile(`(?is)\s+order\s+by\s+.*$`)
+
+func RegisterJiraSprintReportTool(s *server.MCPServer) {
+	jiraSprintReportTool := mcp.NewTool("sprint_report",
+		mcp.WithDescription("Compute a sprint report from issue changelogs: issues committed at start, added mid-sprint, removed, and completed, with story points for each group. In velocity mode, aggregates committed vs completed points over the last closed sprints of a board"),
+		mcp.WithString("mode", mcp.Description("Report mode: 'report' (default) for a single sprint, or 'velocity' for the last closed sprints of a board")),
+		mcp.WithString("sprint_id", mcp.Description("Numeric ID of the sprint to report on (required in report mode)")),
+		mcp.WithString("board_id", mcp.Description("Numeric ID of the agile board (velocity mode)")),
+		mcp.WithString("project_key", mcp.Description("Project key used to look up the board when board_id is not known (velocity mode)")),
+		mcp.WithNumber("sprint_count", mcp.Description("Number of closed sprints to include in velocity mode (default: 5)")),
+	)
+	s.AddTool(jiraSprintReportTool, mcp.NewTypedToolHandler(JiraSprintReportHandler))
+}
+
+func JiraSprintReportHandler(ctx context.Context, request mcp.CallToolRequest, input SprintReportInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	categories, err := statusCategoriesByName(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(input.Mode)) {
+	case "", "report":
+		if input.SprintID == "" {
+			return nil, fmt.Errorf("sprint_id is required in report mode")
+		}
+
+		sprintID, err := parseSprintID(input.SprintID)
+		if err != nil {
+			return nil, err
+		}
+
+		sprint, err := getSprint(ctx, client, sprintID)
+		if err != nil {
+			return nil, err
+		}
+
+		report, err := buildSprintReport(ctx, client, sprint, categories, true)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp.NewToolResultText(formatSprintReport(report)), nil
+	case "velocity":
+		boardID, err := resolveBoardID(ctx, client, input.BoardID, input.ProjectKey, "scrum")
+		if err != nil {
+			return nil, err
+		}
+
+		count := input.SprintCount
+		if count <= 0 {
+			count = 5
+		}
+
+		sprints, err := listBoardSprints(ctx, client, boardID, "closed")
+		if err != nil {
+			return nil, err
+		}
+
+		if len(sprints) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No closed sprints found for board %d.", boardID)), nil
+		}
+
+		// Most recently completed first
+		sort.SliceStable(sprints, func(i, j int) bool {
+			return sprintEnd(&sprints[i]).After(sprintEnd(&sprints[j]))
+		})
+		if len(sprints) > count {
+			sprints = sprints[:count]
+		}
+
+		// Finding removed issues means replaying the changelogs of the whole board filter, which is too
+		// costly to repeat for every sprint, so velocity only covers issues still in each sprint
+		var reports []*sprintReport
+		for index := range sprints {
+			report, err := buildSprintReport(ctx, client, &sprints[index], categories, false)
+			if err != nil {
+				return nil, err
+			}
+			reports = append(reports, report)
+		}
+
+		return mcp.NewToolResultText(formatVelocityReport(boardID, reports)), nil
+	default:
+		return nil, fmt.Errorf("invalid mode %q: must be report or velocity", input.Mode)
+	}
+}
+
+// buildSprintReport classifies every issue that was in the sprint at some point by replaying
+// the Sprint, status, and estimation field changes recorded in the issue changelogs.
+// Issues removed from the sprint are only found when includeRemoved searches the board filter for them
+func buildSprintReport(ctx context.Context, client *jira.Client, sprint *util.Sprint, categories map[string]string, includeRemoved bool) (*sprintReport, error) {
+	if sprint.StartDate == nil || sprint.StartDate.IsZero() || sprint.State == "future" {
+		return nil, fmt.Errorf("sprint %d has not started yet", sprint.ID)
+	}
+
+	start := *sprint.StartDate
+	end := sprintEnd(sprint)
+
+	estimation := resolveEstimationField(ctx, client, sprint.OriginBoardID)
+
+	fields := []string{"summary", "status", "created"}
+	if estimation != nil {
+		fields = append(fields, estimation.ID)
+	}
+
+	// Issues still in the sprint are found directly; issues removed mid-sprint are searched for separately
+	inSprint, err := searchAllIssues(ctx, client, fmt.Sprintf("sprint = %d", sprint.ID), &jira.SearchOptions{
+		Expand: "changelog",
+		Fields: fields,
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string]jira.Issue)
+	currentMembers := make(map[string]bool)
+	for _, issue := range inSprint {
+		candidates[issue.Key] = issue
+		currentMembers[issue.Key] = true
+	}
+
+	report := &sprintReport{
+		Sprint:     sprint,
+		Estimation: estimation,
+	}
+
+	if includeRemoved {
+		others, note := searchRemovedCandidates(ctx, client, sprint, fields)
+		report.RemovedNote = note
+		for _, issue := range others {
+			if _, ok := candidates[issue.Key]; !ok {
+				candidates[issue.Key] = issue
+			}
+		}
+	}
+
+	for _, issue := range candidates {
+		if issue.Fields == nil {
+			continue
+		}
+		if err := util.LoadFullChangelog(ctx, client, &issue); err != nil {
+			return nil, err
+		}
+		report.SkippedHistories += util.SkippedHistories(&issue)
+
+		membership := sprintMembershipChanges(&issue, sprint.ID)
+		created := time.Time(issue.Fields.Created)
+
+		initial := currentMembers[issue.Key]
+		if len(membership) > 0 {
+			initial = membership[0].wasMember
+		}
+
+		memberAt := func(at time.Time) bool {
+			if created.After(at) {
+				return false
+			}
+			member := initial
+			for _, change := range membership {
+				if change.at.After(at) {
+					break
+				}
+				member = change.isMember
+			}
+			return member
+		}
+
+		committed := memberAt(start)
+
+		added := false
+		if !committed {
+			if initial && created.After(start) && !created.After(end) {
+				added = true
+			}
+			for _, change := range membership {
+				if change.at.After(start) && !change.at.After(end) && change.isMember && !change.wasMember {
+					added = true
+				}
+			}
+		}
+
+		if !committed && !added {
+			continue
+		}
+
+		currentStatus := ""
+		if issue.Fields.Status != nil {
+			currentStatus = issue.Fields.Status.Name
+		}
+		endStatus := util.FieldValueAt(util.FieldChanges(&issue, "status"), end, currentStatus)
+		memberAtEnd := memberAt(end)
+
+		scopeIssue := sprintScopeIssue{
+			Key:       issue.Key,
+			Summary:   issue.Fields.Summary,
+			Status:    endStatus,
+			Committed: committed,
+			Added:     added,
+			Removed:   !memberAtEnd,
+			Completed: memberAtEnd && categories[strings.ToLower(endStatus)] == "done",
+		}
+
+		if estimation != nil {
+			current := estimateValue(issue.Fields.Unknowns[estimation.ID])
+			changes := util.FieldChanges(&issue, estimation.Name)
+			scopeIssue.StartValue = parseEstimate(util.FieldValueAt(changes, start, current))
+			scopeIssue.EndValue = parseEstimate(util.FieldValueAt(changes, end, current))
+		}
+
+		report.Issues = append(report.Issues, scopeIssue)
+	}
+
+	sort.Slice(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Key < report.Issues[j].Key
+	})
+
+	return report, nil
+}
+
+// searchRemovedCandidates finds issues of the board filter updated since the sprint started; issues removed
+// mid-sprint no longer match "sprint = X". The note explains why the result may be incomplete, if it is
+func searchRemovedCandidates(ctx context.Context, client *jira.Client, sprint *util.Sprint, fields []string) ([]jira.Issue, string) {
+	filterJQL, err := boardFilterJQL(ctx, client, sprint.OriginBoardID)
+	if err != nil {
+		return nil, fmt.Sprintf("the board filter is unavailable (%v)", err)
+	}
+
+	since := sprint.StartDate.AddDate(0, 0, -1).Format("2006/01/02 15:04")
+	removedJQL := fmt.Sprintf("(%s) AND updated >= \"%s\"", filterJQL, since)
+	// One extra issue tells whether the search was cut off
+	others, err := searchAllIssues(ctx, client, removedJQL, &jira.SearchOptions{
+		Expand: "changelog",
+		Fields: fields,
+	}, maxRemovedCandidates+1)
+	if err != nil {
+		return nil, fmt.Sprintf("the board filter search failed (%v)", err)
+	}
+	if len(others) > maxRemovedCandidates {
+		return others[:maxRemovedCandidates], fmt.Sprintf("only the first %d issues of the board filter updated since the sprint started were checked", maxRemovedCandidates)
+	}
+	return others, ""
+}
+
+// sprintMembershipChange records whether an issue entered or left a sprint
+type sprintMembershipChange struct {
+	at        time.Time
+	wasMember bool
+	isMember  bool
+}
+
+// sprintMembershipChanges extracts the Sprint field changes that affect a given sprint
+func sprintMembershipChanges(issue *jira.Issue, sprintID int) []sprintMembershipChange {
+	var changes []sprintMembershipChange
+	for _, change := range util.FieldChanges(issue, "Sprint") {
+		wasMember := containsSprintID(change.From, sprintID)
+		isMember := containsSprintID(change.To, sprintID)
+		if wasMember != isMember {
+			changes = append(changes, sprintMembershipChange{
+				at:        change.At,
+				wasMember: wasMember,
+				isMember:  isMember,
+			})
+		}
+	}
+	return changes
+}
+
+// containsSprintID checks a changelog Sprint value such as "12, 15" for a sprint ID
+func containsSprintID(value string, sprintID int) bool {
+	for _, part := range strings.Split(value, ",") {
+		if id, err := strconv.Atoi(strings.TrimSpace(part)); err == nil && id == sprintID {
+			return true
+		}
+	}
+	return false
+}
+
+// sprintEnd returns when the sprint scope is evaluated: completion for closed sprints, now otherwise
+func sprintEnd(sprint *util.Sprint) time.Time {
+	if sprint.CompleteDate != nil && !sprint.CompleteDate.IsZero() {
+		return *sprint.CompleteDate
+	}
+	if sprint.State == "closed" && sprint.EndDate != nil && !sprint.EndDate.IsZero() {
+		return *sprint.EndDate
+	}
+	return time.Now()
+}
+
+// resolveEstimationField prefers the estimation field configured on the board and falls back
+// to the instance-wide Story Points field. Returns nil when issues are not estimated
+func resolveEstimationField(ctx context.Context, client *jira.Client, boardID int) *estimationField {
+	if boardID != 0 {
+		config, err := getBoardConfiguration(ctx, client, boardID)
+		if err == nil && config.Estimation != nil && config.Estimation.Field != nil {
+			return &estimationField{
+				ID:   config.Estimation.Field.FieldID,
+				Name: config.Estimation.Field.DisplayName,
+			}
+		}
+	}
+
+	field, err := util.DiscoverStoryPointsField(ctx, client)
+	if err != nil {
+		return nil
+	}
+
+	return &estimationField{ID: field.ID, Name: field.Name}
+}
+
+// boardFilterJQL returns the board filter JQL without its ORDER BY clause
+func boardFilterJQL(ctx context.Context, client *jira.Client, boardID int) (string, error) {
+	if boardID == 0 {
+		return "", fmt.Errorf("the sprint has no origin board")
+	}
+
+	config, err := getBoardConfiguration(ctx, client, boardID)
+	if err != nil {
+		return "", err
+	}
+
+	filterID, err := strconv.Atoi(config.Filter.ID)
+	if err != nil {
+		return "", fmt.Errorf("board %d has no valid filter ID: %q", boardID, config.Filter.ID)
+	}
+
+	filter, _, err := client.Filter.GetWithContext(ctx, filterID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get filter %d: %v", filterID, err)
+	}
+
+	filterJQL := strings.TrimSpace(orderByPattern.ReplaceAllString(filter.Jql, ""))
+	if filterJQL == "" {
+		return "", fmt.Errorf("filter %d has no JQL", filterID)
+	}
+	return filterJQL, nil
+}
+
+// statusCategoriesByName maps lower-cased status names to their status category key (new, indeterminate, done)
+func statusCategoriesByName(ctx context.Context, client *jira.Client) (map[string]string, error) {
+	statuses, _, err := client.Status.GetAllStatusesWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses: %v", err)
+	}
+
+	categories := make(map[string]string, len(statuses))
+	for _, status := range statuses {
+		categories[strings.ToLower(status.Name)] = status.StatusCategory.Key
+	}
+
+	return categories, nil
+}
+
+// estimateValue renders a raw estimation field value the way the changelog stores it
+func estimateValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
+
+func parseEstimate(value string) float64 {
+	estimate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
+	}
+	return estimate
+}
+
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
+
+// sprintReportTotals sums issue counts and estimates for each scope group
+type sprintReportTotals struct {
+	CommittedIssues, AddedIssues, RemovedIssues, CompletedIssues, IncompleteIssues int
+	CommittedPoints, AddedPoints, RemovedPoints, CompletedPoints, IncompletePoints float64
+}
+
+func (r *sprintReport) totals() sprintReportTotals {
+	var totals sprintReportTotals
+	for _, issue := range r.Issues {
+		if issue.Committed {
+			totals.CommittedIssues++
+			totals.CommittedPoints += issue.StartValue
+		}
+		if issue.Added {
+			totals.AddedIssues++
+			totals.AddedPoints += issue.EndValue
+		}
+		if issue.Removed {
+			totals.RemovedIssues++
+			totals.RemovedPoints += issue.EndValue
+		}
+		if issue.Completed {
+			totals.CompletedIssues++
+			totals.CompletedPoints += issue.EndValue
+		} else if !issue.Removed {
+			totals.IncompleteIssues++
+			totals.IncompletePoints += issue.EndValue
+		}
+	}
+	return totals
+}
+
+func formatSprintReport(report *sprintReport) string {
+	var sb strings.Builder
+
+	sprint := report.Sprint
+	sb.WriteString(fmt.Sprintf("Sprint Report: %s (ID: %d)\n", sprint.Name, sprint.ID))
+	sb.WriteString(fmt.Sprintf("State: %s\n", sprint.State))
+	if sprint.Goal != "" {
+		sb.WriteString(fmt.Sprintf("Goal: %s\n", sprint.Goal))
+	}
+	sb.WriteString(fmt.Sprintf("Start Date: %s\n", sprint.StartDate.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("Evaluated At: %s\n", sprintEnd(sprint).Format("2006-01-02 15:04:05")))
+
+	pointsLabel := "Story Points"
+	if report.Estimation != nil {
+		pointsLabel = report.Estimation.Name
+		sb.WriteString(fmt.Sprintf("Estimation Field: %s (%s)\n", report.Estimation.Name, report.Estimation.ID))
+	} else {
+		sb.WriteString("Estimation Field: None (only issue counts are reported)\n")
+	}
+
+	totals := report.totals()
+	sb.WriteString(fmt.Sprintf("\n| Group | Issues | %s |\n|---|---|---|\n", pointsLabel))
+	sb.WriteString(fmt.Sprintf("| Committed at start | %d | %s |\n", totals.CommittedIssues, formatPoints(totals.CommittedPoints)))
+	sb.WriteString(fmt.Sprintf("| Added mid-sprint | %d | %s |\n", totals.AddedIssues, formatPoints(totals.AddedPoints)))
+	sb.WriteString(fmt.Sprintf("| Removed | %d | %s |\n", totals.RemovedIssues, formatPoints(totals.RemovedPoints)))
+	sb.WriteString(fmt.Sprintf("| Completed | %d | %s |\n", totals.CompletedIssues, formatPoints(totals.CompletedPoints)))
+	sb.WriteString(fmt.Sprintf("| Not completed | %d | %s |\n", totals.IncompleteIssues, formatPoints(totals.IncompletePoints)))
+	if report.RemovedNote != "" {
+		sb.WriteString(fmt.Sprintf("\nNote: Removed issues may be incomplete: %s\n", report.RemovedNote))
+	}
+	if report.SkippedHistories > 0 {
+		sb.WriteString("\n" + skippedHistoriesNote(report.SkippedHistories))
+	}
+
+	groups := []struct {
+		title   string
+		include func(sprintScopeIssue) bool
+	}{
+		{"Committed at start", func(i sprintScopeIssue) bool { return i.Committed }},
+		{"Added mid-sprint", func(i sprintScopeIssue) bool { return i.Added }},
+		{"Removed", func(i sprintScopeIssue) bool { return i.Removed }},
+		{"Completed", func(i sprintScopeIssue) bool { return i.Completed }},
+		{"Not completed", func(i sprintScopeIssue) bool { return !i.Completed && !i.Removed }},
+	}
+
+	for _, group := range groups {
+		var lines []string
+		for _, issue := range report.Issues {
+			if !group.include(issue) {
+				continue
+			}
+			line := fmt.Sprintf("- %s: %s [%s]", issue.Key, issue.Summary, issue.Status)
+			if report.Estimation != nil {
+				line += fmt.Sprintf(" (%s)", formatPoints(issue.EndValue))
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n%s:\n%s\n", group.title, strings.Join(lines, "\n")))
+	}
+
+	return sb.String()
+}
+
+func formatVelocityReport(boardID int, reports []*sprintReport) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Velocity for board %d (last %d closed sprints):\n\n", boardID, len(reports)))
+	sb.WriteString("| Sprint | Completed At | Committed Issues | Completed Issues | Committed Points | Added Points | Completed Points |\n")
+	sb.WriteString("|---|---|---|---|---|---|---|\n")
+
+	var committedSum, completedSum float64
+	for _, report := range reports {
+		totals := report.totals()
+		committedSum += totals.CommittedPoints
+		completedSum += totals.CompletedPoints
+
+		sb.WriteString(fmt.Sprintf("| %s (ID: %d) | %s | %d | %d | %s | %s | %s |\n",
+			report.Sprint.Name,
+			report.Sprint.ID,
+			sprintEnd(report.Sprint).Format("2006-01-02"),
+			totals.CommittedIssues,
+			totals.CompletedIssues,
+			formatPoints(totals.CommittedPoints),
+			formatPoints(totals.AddedPoints),
+			formatPoints(totals.CompletedPoints)))
+	}
+
+	sb.WriteString("\nNote: Issues removed from a sprint are not searched for in velocity mode, so committed counts only cover issues still in each sprint; use report mode for a single sprint's scope changes\n")
+
+	for _, report := range reports {
+		if report.SkippedHistories > 0 {
+			sb.WriteString(fmt.Sprintf("\nNote: %d changelog entries with unreadable timestamps were skipped for %s\n", report.SkippedHistories, report.Sprint.Name))
+		}
+	}
+
+	count := float64(len(reports))
+	sb.WriteString(fmt.Sprintf("\nAverage Committed Points: %.1f\n", committedSum/count))
+	sb.WriteString(fmt.Sprintf("Average Completed Points (velocity): %.1f\n", completedSum/count))
+	if committedSum > 0 {
+		sb.WriteString(fmt.Sprintf("Say/Do Ratio: %.0f%%\n", completedSum/committedSum*100))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type ListStatusesInput struct {
+	ProjectKey string `json:"project_key" validate:"required"`
+	IssueType  string `json:"issue_type,omitempty"`
+}
+
+func RegisterJiraStatusTool(s *server.MCPServer) {
+	jiraListStatusesTool := mcp.NewTool("list_statuses",
+		mcp.WithDescription("List the statuses available in a project per issue type, with their status category (To Do, In Progress, Done). Use this to find valid status names before writing JQL or transitioning issues"),
+		mcp.WithString("project_key", mcp.Required(), mcp.Description("Project identifier (e.g., KP, PROJ)")),
+		mcp.WithString("issue_type", mcp.Description("Only list statuses for this issue type (e.g., Bug, Story)")),
+	)
+	s.AddTool(jiraListStatusesTool, mcp.NewTypedToolHandler(JiraListStatusesHandler))
+}
+
+func JiraListStatusesHandler(ctx context.Context, request mcp.CallToolRequest, input ListStatusesInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	issueTypes, err := getProjectStatuses(ctx, client, input.ProjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.IssueType != "" {
+		var filtered []util.IssueTypeStatuses
+		var available []string
+		for _, issueType := range issueTypes {
+			available = append(available, issueType.Name)
+			if strings.EqualFold(issueType.Name, input.IssueType) {
+				filtered = append(filtered, issueType)
+			}
+		}
+		if len(filtered) == 0 {
+			return nil, fmt.Errorf("issue type %q not found in project %s. Available issue types: %s", input.IssueType, input.ProjectKey, strings.Join(available, ", "))
+		}
+		issueTypes = filtered
+	}
+
+	if len(issueTypes) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No statuses found for project %s.", input.ProjectKey)), nil
+	}
+
+	return mcp.NewToolResultText(util.FormatProjectStatuses(strings.ToUpper(input.ProjectKey), issueTypes)), nil
+}
+
+// getProjectStatuses fetches the statuses of every issue type in a project
+func getProjectStatuses(ctx context.Context, client *jira.Client, projectKey string) ([]util.IssueTypeStatuses, error) {
+	req, err := client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("rest/api/2/project/%s/statuses", projectKey), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	var issueTypes []util.IssueTypeStatuses
+	response, err := client.Do(req, &issueTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project statuses: %v", jira.NewJiraError(response, err))
+	}
+
+	return issueTypes, nil
+}
@@ -0,0 +1,241 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type TimesheetInput struct {
+	JQL       string `json:"jql,omitempty"`
+	User      string `json:"user,omitempty"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+	MaxIssues int    `json:"max_issues,omitempty"`
+}
+
+// defaultTimesheetMaxIssues caps the number of issues whose worklogs are collected
+const defaultTimesheetMaxIssues = 200
+
+// timesheetTotal accumulates logged time for one group (day, issue or author)
+type timesheetTotal struct {
+	Label   string
+	Seconds int
+	Entries int
+}
+
+func RegisterJiraTimesheetTool(s *server.MCPServer) {
+	jiraTimesheetTool := mcp.NewTool("timesheet",
+		mcp.WithDescription("Aggregate worklogs across issues for a date range. Returns time logged per day, per issue, and per author. Provide a JQL query, a user, or both"),
+		mcp.WithString("jql", mcp.Description("JQL query selecting the issues to include (e.g., 'project = KP')")),
+		mcp.WithString("user", mcp.Description("Only include worklogs by this user (username, email, display name, or 'currentUser()')")),
+		mcp.WithString("start_date", mcp.Description("First day of the range (e.g., '2024-01-15'). Defaults to 7 days ago")),
+		mcp.WithString("end_date", mcp.Description("Last day of the range, inclusive (e.g., '2024-01-21'). Defaults to today")),
+		mcp.WithNumber("max_issues", mcp.Description(fmt.Sprintf("Maximum number of issues to collect worklogs from (default: %d)", defaultTimesheetMaxIssues))),
+	)
+	s.AddTool(jiraTimesheetTool, mcp.NewTypedToolHandler(JiraTimesheetHandler))
+}
+
+func JiraTimesheetHandler(ctx context.Context, request mcp.CallToolRequest, input TimesheetInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	jql := strings.TrimSpace(input.JQL)
+	user := strings.TrimSpace(input.User)
+	if jql == "" && user == "" {
+		return nil, fmt.Errorf("either jql or user is required")
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, -7)
+	if input.StartDate != "" {
+		start, err := util.ParseDateTime(input.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_date: %v", err)
+		}
+		from = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+	}
+
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if input.EndDate != "" {
+		end, err := util.ParseDateTime(input.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_date: %v", err)
+		}
+		to = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.Local)
+	}
+
+	if to.Before(from) {
+		return nil, fmt.Errorf("end_date must not be before start_date")
+	}
+	// Compare against the start of the following day so the end date is inclusive
+	until := to.AddDate(0, 0, 1)
+
+	// Resolve currentUser() so worklog authors can be matched locally
+	var author *jira.User
+	if user != "" {
+		author = &jira.User{Name: user}
+		if strings.EqualFold(user, "currentUser()") || strings.EqualFold(user, "me") {
+			self, _, err := client.User.GetSelfWithContext(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get current user: %v", err)
+			}
+			author = self
+		}
+	}
+
+	clauses := []string{
+		fmt.Sprintf("worklogDate >= %s", quoteJQL(from.Format("2006-01-02"))),
+		fmt.Sprintf("worklogDate <= %s", quoteJQL(to.Format("2006-01-02"))),
+	}
+	if jql != "" {
+		clauses = append([]string{fmt.Sprintf("(%s)", orderByPattern.ReplaceAllString(jql, ""))}, clauses...)
+	}
+	if author != nil {
+		if author.AccountID != "" {
+			clauses = append(clauses, fmt.Sprintf("worklogAuthor = %s", quoteJQL(author.AccountID)))
+		} else if author.Name != "" {
+			clauses = append(clauses, fmt.Sprintf("worklogAuthor = %s", quoteJQL(author.Name)))
+		}
+	}
+
+	maxIssues := input.MaxIssues
+	if maxIssues <= 0 {
+		maxIssues = defaultTimesheetMaxIssues
+	}
+
+	// One issue past the limit tells whether the totals are complete
+	issues, err := searchAllIssues(ctx, client, strings.Join(clauses, " AND "), &jira.SearchOptions{
+		Fields: []string{"summary"},
+	}, maxIssues+1)
+	if err != nil {
+		return nil, err
+	}
+	truncated := len(issues) > maxIssues
+	if truncated {
+		issues = issues[:maxIssues]
+	}
+
+	if len(issues) == 0 {
+		return mcp.NewToolResultText("No worklogs found matching the criteria."), nil
+	}
+
+	byDay := make(map[string]*timesheetTotal)
+	byIssue := make(map[string]*timesheetTotal)
+	byAuthor := make(map[string]*timesheetTotal)
+	totalSeconds := 0
+	totalEntries := 0
+
+	for _, issue := range issues {
+		worklogs, err := getIssueWorklogs(ctx, client, issue.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		summary := ""
+		if issue.Fields != nil {
+			summary = issue.Fields.Summary
+		}
+
+		for _, worklog := range worklogs {
+			if worklog.Started == nil {
+				continue
+			}
+			started := time.Time(*worklog.Started).In(time.Local)
+			if started.Before(from) || !started.Before(until) {
+				continue
+			}
+			if author != nil && !worklogByUser(&worklog, author) {
+				continue
+			}
+
+			authorName := "Unknown"
+			if worklog.Author != nil && worklog.Author.DisplayName != "" {
+				authorName = worklog.Author.DisplayName
+			}
+
+			day := started.Format("2006-01-02 (Mon)")
+			addTimesheetEntry(byDay, day, day, worklog.TimeSpentSeconds)
+			addTimesheetEntry(byIssue, issue.Key, fmt.Sprintf("%s: %s", issue.Key, summary), worklog.TimeSpentSeconds)
+			addTimesheetEntry(byAuthor, authorName, authorName, worklog.TimeSpentSeconds)
+			totalSeconds += worklog.TimeSpentSeconds
+			totalEntries++
+		}
+	}
+
+	if totalEntries == 0 {
+		return mcp.NewToolResultText("No worklogs found matching the criteria."), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Timesheet from %s to %s\n", from.Format("2006-01-02"), to.Format("2006-01-02")))
+	if user != "" {
+		sb.WriteString(fmt.Sprintf("User: %s\n", user))
+	}
+	if jql != "" {
+		sb.WriteString(fmt.Sprintf("JQL: %s\n", jql))
+	}
+	sb.WriteString(fmt.Sprintf("Total: %s across %d worklogs on %d issues\n", util.FormatDuration(totalSeconds), totalEntries, len(byIssue)))
+	if truncated {
+		sb.WriteString(fmt.Sprintf("Note: only the first %d matching issues were included; raise max_issues or narrow the query for complete totals\n", maxIssues))
+	}
+
+	writeTimesheetTable(&sb, "Per Day", "Day", byDay, func(a, b *timesheetTotal) bool { return a.Label < b.Label })
+	writeTimesheetTable(&sb, "Per Issue", "Issue", byIssue, func(a, b *timesheetTotal) bool { return a.Seconds > b.Seconds })
+	writeTimesheetTable(&sb, "Per Author", "Author", byAuthor, func(a, b *timesheetTotal) bool { return a.Seconds > b.Seconds })
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// worklogByUser checks whether a worklog was written by the given user, matching any known identifier
+func worklogByUser(worklog *jira.WorklogRecord, user *jira.User) bool {
+	if worklog.Author == nil {
+		return false
+	}
+
+	for _, candidate := range []string{user.AccountID, user.Key, user.Name, user.EmailAddress, user.DisplayName} {
+		if candidate == "" {
+			continue
+		}
+		for _, value := range []string{worklog.Author.AccountID, worklog.Author.Key, worklog.Author.Name, worklog.Author.EmailAddress, worklog.Author.DisplayName} {
+			if strings.EqualFold(candidate, value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func addTimesheetEntry(totals map[string]*timesheetTotal, key string, label string, seconds int) {
+	total, ok := totals[key]
+	if !ok {
+		total = &timesheetTotal{Label: label}
+		totals[key] = total
+	}
+	total.Seconds += seconds
+	total.Entries++
+}
+
+func writeTimesheetTable(sb *strings.Builder, title string, column string, totals map[string]*timesheetTotal, less func(a, b *timesheetTotal) bool) {
+	rows := make([]*timesheetTotal, 0, len(totals))
+	for _, total := range totals {
+		rows = append(rows, total)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return less(rows[i], rows[j])
+	})
+
+	sb.WriteString(fmt.Sprintf("\n%s:\n| %s | Time | Hours | Worklogs |\n|---|---|---|---|\n", title, column))
+	for _, row := range rows {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %.2f | %d |\n", util.EscapeTableCell(row.Label), util.FormatDuration(row.Seconds), float64(row.Seconds)/3600, row.Entries))
+	}
+}
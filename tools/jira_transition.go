@@ -3,25 +3,53 @@ package tools
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 
+	"github.com/andygrunwald/go-jira"
 	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 // Input types for typed tools
 type TransitionIssueInput struct {
-	IssueKey     string `json:"issue_key" validate:"required"`
-	TransitionID string `json:"transition_id" validate:"required"`
-	Comment      string `json:"comment,omitempty"`
+	IssueKey     string                 `json:"issue_key" validate:"required"`
+	TransitionID string                 `json:"transition_id,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+	Comment      string                 `json:"comment,omitempty"`
+	Mode         string                 `json:"mode,omitempty"`
+	Resolution   string                 `json:"resolution,omitempty"`
+	Assignee     string                 `json:"assignee,omitempty"`
+	FixVersions  string                 `json:"fix_versions,omitempty"`
+	Fields       map[string]interface{} `json:"fields,omitempty"`
+}
+
+// workflowSampleIssues caps the issues whose changelogs are used to learn a workflow
+const workflowSampleIssues = 100
+
+// transitionHop is one transition applied while walking a workflow path
+type transitionHop struct {
+	From       string
+	To         string
+	Transition string
+	ID         string
 }
 
 func RegisterJiraTransitionTool(s *server.MCPServer) {
 	jiraTransitionTool := mcp.NewTool("transition_issue",
-		mcp.WithDescription("Transition an issue through its workflow using a valid transition ID. Get available transitions from jira_get_issue"),
+		mcp.WithDescription("Transition an issue through its workflow. Provide either the target status name (e.g., 'In Progress'), a transition name (e.g., 'Start Progress'), or a transition ID"),
 		mcp.WithString("issue_key", mcp.Required(), mcp.Description("The issue to transition (e.g., KP-123)")),
-		mcp.WithString("transition_id", mcp.Required(), mcp.Description("Transition ID from available transitions list")),
+		mcp.WithString("status", mcp.Description("Target status name or transition name (e.g., 'In Progress', 'Done', 'Start Progress')")),
+		mcp.WithString("transition_id", mcp.Description("Transition ID from available transitions list (alternative to status)")),
 		mcp.WithString("comment", mcp.Description("Optional comment to add with transition")),
+		mcp.WithString("mode", mcp.Description("'direct' (default) applies a single transition. 'path' finds the shortest route through the workflow to the target status and applies each transition in order")),
+		mcp.WithString("resolution", mcp.Description("Resolution to set on the transition screen (e.g., 'Fixed', 'Done', 'Won't Do')")),
+		mcp.WithString("assignee", mcp.Description("Assignee to set on the transition screen")),
+		mcp.WithString("fix_versions", mcp.Description("Comma-separated fix versions to set on the transition screen (e.g., '1.4.0, 1.4.1')")),
+		mcp.WithObject("fields", mcp.Description("Other transition screen fields by ID or name, e.g. {\"customfield_10200\": \"value\", \"Root Cause\": \"Config\"}. Values are validated against the transition screen")),
 	)
 	s.AddTool(jiraTransitionTool, mcp.NewTypedToolHandler(JiraTransitionIssueHandler))
 }
@@ -29,35 +57,432 @@ func RegisterJiraTransitionTool(s *server.MCPServer) {
 func JiraTransitionIssueHandler(ctx context.Context, request mcp.CallToolRequest, input TransitionIssueInput) (*mcp.CallToolResult, error) {
 	client := services.JiraClient()
 
-	// Use a simplified transition approach via custom request
+	values, err := transitionFieldValues(input.Fields, input.Resolution, input.Assignee, input.FixVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(input.Mode)) {
+	case "", "direct":
+	case "path":
+		if strings.TrimSpace(input.Status) == "" {
+			return nil, fmt.Errorf("status is required in path mode")
+		}
+		return transitionAlongPath(ctx, client, input.IssueKey, strings.TrimSpace(input.Status), input.Comment, values)
+	default:
+		return nil, fmt.Errorf("invalid mode %q: must be 'direct' or 'path'", input.Mode)
+	}
+
+	if input.TransitionID == "" && strings.TrimSpace(input.Status) == "" {
+		return nil, fmt.Errorf("either status or transition_id is required")
+	}
+
+	transitions, _, err := client.Issue.GetTransitionsWithContext(ctx, input.IssueKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transitions: %v", err)
+	}
+
+	var transition *jira.Transition
+	if input.TransitionID != "" {
+		for index := range transitions {
+			if transitions[index].ID == input.TransitionID {
+				transition = &transitions[index]
+				break
+			}
+		}
+		if transition == nil {
+			return nil, fmt.Errorf("transition %s is not available for issue %s. Available transitions:\n%s", input.TransitionID, input.IssueKey, formatTransitions(transitions))
+		}
+	} else {
+		transition, err = resolveTransition(transitions, input.Status)
+		if err != nil {
+			return nil, fmt.Errorf("%v for issue %s", err, input.IssueKey)
+		}
+	}
+
+	fields, screen, err := prepareTransitionFields(ctx, client, input.IssueKey, transition, values)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyTransition(ctx, client, input.IssueKey, transition.ID, input.Comment, fields); err != nil {
+		return nil, transitionFailure(ctx, client, input.IssueKey, transition, screen, fields, err)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Issue %s transitioned to %s via '%s' (ID: %s)", input.IssueKey, transition.To.Name, transition.Name, transition.ID)), nil
+}
+
+// transitionFieldValues merges the field shortcuts into the raw fields map
+func transitionFieldValues(raw map[string]interface{}, resolution string, assignee string, fixVersions string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(raw)+3)
+	for field, value := range raw {
+		values[field] = value
+	}
+
+	shortcuts := []struct {
+		field string
+		value string
+	}{
+		{"resolution", resolution},
+		{"assignee", assignee},
+		{"fixVersions", fixVersions},
+	}
+	for _, shortcut := range shortcuts {
+		if strings.TrimSpace(shortcut.value) == "" {
+			continue
+		}
+		for field := range values {
+			if strings.EqualFold(field, shortcut.field) {
+				return nil, fmt.Errorf("%s is set both as a parameter and in fields; use only one", shortcut.field)
+			}
+		}
+		values[shortcut.field] = strings.TrimSpace(shortcut.value)
+	}
+
+	return values, nil
+}
+
+// prepareTransitionFields validates field values against the transition screen and converts them to the
+// shape Jira expects. Fields may be addressed by ID or display name; option values by name or ID.
+// The screen is only loaded when values are given, and is returned so a rejected transition can be explained
+func prepareTransitionFields(ctx context.Context, client *jira.Client, issueKey string, transition *jira.Transition, values map[string]interface{}) (map[string]interface{}, map[string]fieldMeta, error) {
+	if len(values) == 0 {
+		return nil, nil, nil
+	}
+
+	screen, err := getTransitionScreen(ctx, client, issueKey, transition.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		fieldID, meta := findFieldMeta(screen, name)
+		if meta == nil {
+			return nil, nil, fmt.Errorf("field %q is not on the '%s' transition screen. Available fields:\n%s", name, transition.Name, formatFieldMetas(screen))
+		}
+
+		converted, err := fieldValue(meta, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields[fieldID] = converted
+	}
+
+	return fields, screen, nil
+}
+
+// getTransitionScreen fetches the screen fields of a single transition
+func getTransitionScreen(ctx context.Context, client *jira.Client, issueKey string, transitionID string) (map[string]fieldMeta, error) {
+	endpoint := fmt.Sprintf("rest/api/2/issue/%s/transitions?expand=transitions.fields&transitionId=%s", issueKey, url.QueryEscape(transitionID))
+	req, err := client.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	var result struct {
+		Transitions []struct {
+			ID     string               `json:"id"`
+			Fields map[string]fieldMeta `json:"fields"`
+		} `json:"transitions"`
+	}
+	response, err := client.Do(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transition fields: %v", jira.NewJiraError(response, err))
+	}
+
+	for _, transition := range result.Transitions {
+		if transition.ID == transitionID {
+			return transition.Fields, nil
+		}
+	}
+
+	return nil, fmt.Errorf("transition %s is not available for issue %s", transitionID, issueKey)
+}
+
+// transitionFailure explains a transition Jira rejected by naming the required screen fields that have no default
+// and were not provided. Jira decides whether a transition needs them, since the issue may already hold a value,
+// so they are only reported once it fails. A nil screen is loaded here
+func transitionFailure(ctx context.Context, client *jira.Client, issueKey string, transition *jira.Transition, screen map[string]fieldMeta, fields map[string]interface{}, err error) error {
+	if screen == nil {
+		loaded, screenErr := getTransitionScreen(ctx, client, issueKey, transition.ID)
+		if screenErr != nil {
+			return err
+		}
+		screen = loaded
+	}
+
+	var missing []string
+	for fieldID, meta := range screen {
+		if _, ok := fields[fieldID]; ok || !meta.Required || meta.HasDefaultValue {
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("%s (%s)", meta.Name, fieldID))
+	}
+	if len(missing) == 0 {
+		return err
+	}
+
+	sort.Strings(missing)
+	return fmt.Errorf("%v. The '%s' transition screen requires: %s", err, transition.Name, strings.Join(missing, ", "))
+}
+
+// transitionAlongPath moves an issue to the target status through as many transitions as needed.
+// The route is the shortest path through the workflow as observed in the changelogs of similar issues.
+// Field values apply to the final transition. It stops at the first hop Jira rejects, naming the required
+// screen fields that were not provided
+func transitionAlongPath(ctx context.Context, client *jira.Client, issueKey string, target string, comment string, values map[string]interface{}) (*mcp.CallToolResult, error) {
+	issue, _, err := client.Issue.GetWithContext(ctx, issueKey, &jira.GetQueryOptions{Fields: "status,project,issuetype"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %v", err)
+	}
+
+	if issue.Fields == nil || issue.Fields.Status == nil {
+		return nil, fmt.Errorf("failed to determine the current status of issue %s", issueKey)
+	}
+
+	current := issue.Fields.Status.Name
+	if strings.EqualFold(current, target) {
+		return mcp.NewToolResultText(fmt.Sprintf("Issue %s is already in status %s", issueKey, current)), nil
+	}
+
+	transitions, _, err := client.Issue.GetTransitionsWithContext(ctx, issueKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transitions: %v", err)
+	}
+
+	path, err := workflowPath(ctx, client, issue, transitions, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var hops []transitionHop
+	for _, next := range path {
+		// Take a direct transition to the target as soon as one becomes available
+		transition := findTransitionTo(transitions, target)
+		if transition == nil {
+			transition = findTransitionTo(transitions, next)
+		}
+		if transition == nil {
+			return nil, fmt.Errorf("%sno transition from %s to %s is available. Available transitions:\n%s", formatHops(issueKey, hops), current, next, formatTransitions(transitions))
+		}
+
+		final := strings.EqualFold(transition.To.Name, target)
+
+		var fields map[string]interface{}
+		var screen map[string]fieldMeta
+		if final {
+			fields, screen, err = prepareTransitionFields(ctx, client, issueKey, transition, values)
+			if err != nil {
+				return nil, fmt.Errorf("%s%v", formatHops(issueKey, hops), err)
+			}
+		}
+
+		hopComment := ""
+		if final {
+			hopComment = comment
+		}
+		if err := applyTransition(ctx, client, issueKey, transition.ID, hopComment, fields); err != nil {
+			err = transitionFailure(ctx, client, issueKey, transition, screen, fields, err)
+			return nil, fmt.Errorf("%sstopped at '%s' (%s → %s): %v", formatHops(issueKey, hops), transition.Name, current, transition.To.Name, err)
+		}
+
+		hops = append(hops, transitionHop{From: current, To: transition.To.Name, Transition: transition.Name, ID: transition.ID})
+		current = transition.To.Name
+		if strings.EqualFold(current, target) {
+			break
+		}
+
+		transitions, _, err = client.Issue.GetTransitionsWithContext(ctx, issueKey)
+		if err != nil {
+			return nil, fmt.Errorf("%sfailed to get transitions: %v", formatHops(issueKey, hops), err)
+		}
+	}
+
+	if !strings.EqualFold(current, target) {
+		return nil, fmt.Errorf("%sthe workflow path ended in %s instead of %s", formatHops(issueKey, hops), current, target)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Issue %s transitioned to %s in %d steps:\n%s", issueKey, current, len(hops), formatHopList(hops))), nil
+}
+
+// workflowPath returns the statuses to pass through, ending with the target, on the shortest route from the
+// issue's current status. Edges come from the issue's available transitions and from status changes recorded
+// in the changelogs of issues with the same project and issue type
+func workflowPath(ctx context.Context, client *jira.Client, issue *jira.Issue, transitions []jira.Transition, target string) ([]string, error) {
+	edges := make(map[string]map[string]string)
+	addEdge := func(from, to string) {
+		if from == "" || to == "" {
+			return
+		}
+		key := strings.ToLower(from)
+		if edges[key] == nil {
+			edges[key] = make(map[string]string)
+		}
+		edges[key][strings.ToLower(to)] = to
+	}
+
+	current := issue.Fields.Status.Name
+	for _, transition := range transitions {
+		addEdge(current, transition.To.Name)
+	}
+
+	jql := fmt.Sprintf("project = %s AND issuetype = %s AND status CHANGED", quoteJQL(issue.Fields.Project.Key), quoteJQL(issue.Fields.Type.Name))
+	samples, err := searchAllIssues(ctx, client, jql, &jira.SearchOptions{
+		Fields: []string{"status"},
+		Expand: "changelog",
+	}, workflowSampleIssues)
+	if err != nil {
+		return nil, err
+	}
+	for index := range samples {
+		for _, change := range util.FieldChanges(&samples[index], "status") {
+			addEdge(change.FromString, change.ToString)
+		}
+	}
+
+	// Breadth-first search over lower-cased status names
+	start, goal := strings.ToLower(current), strings.ToLower(target)
+	previous := map[string]string{start: ""}
+	queue := []string{start}
+	for len(queue) > 0 && previous[goal] == "" {
+		status := queue[0]
+		queue = queue[1:]
+		for next := range edges[status] {
+			if _, ok := previous[next]; ok {
+				continue
+			}
+			previous[next] = status
+			queue = append(queue, next)
+		}
+	}
+
+	if _, ok := previous[goal]; !ok {
+		known := make([]string, 0, len(previous))
+		for status := range previous {
+			if status != start {
+				known = append(known, status)
+			}
+		}
+		sort.Strings(known)
+		return nil, fmt.Errorf("no known workflow path from %s to %s for issue %s. Statuses reachable from %s: %s", current, target, issue.Key, current, strings.Join(known, ", "))
+	}
+
+	var path []string
+	for status := goal; status != start; status = previous[status] {
+		path = append([]string{edges[previous[status]][status]}, path...)
+	}
+
+	return path, nil
+}
+
+// findTransitionTo returns the available transition leading to the named status
+func findTransitionTo(transitions []jira.Transition, status string) *jira.Transition {
+	for index := range transitions {
+		if strings.EqualFold(transitions[index].To.Name, status) {
+			return &transitions[index]
+		}
+	}
+	return nil
+}
+
+// formatHops prefixes an error with the transitions already applied, so partial progress is never hidden
+func formatHops(issueKey string, hops []transitionHop) string {
+	if len(hops) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("issue %s was moved through %d steps:\n%s\nthen ", issueKey, len(hops), formatHopList(hops))
+}
+
+func formatHopList(hops []transitionHop) string {
+	var sb strings.Builder
+	for index, hop := range hops {
+		sb.WriteString(fmt.Sprintf("%d. %s → %s via '%s' (ID: %s)\n", index+1, hop.From, hop.To, hop.Transition, hop.ID))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// resolveTransition finds the single transition whose target status or name matches value.
+// The error lists the valid options when nothing or more than one transition matches
+func resolveTransition(transitions []jira.Transition, value string) (*jira.Transition, error) {
+	value = strings.TrimSpace(value)
+
+	var matches []*jira.Transition
+	for index := range transitions {
+		if strings.EqualFold(transitions[index].To.Name, value) {
+			matches = append(matches, &transitions[index])
+		}
+	}
+	// Fall back to transition names only when no target status matches
+	if len(matches) == 0 {
+		for index := range transitions {
+			if strings.EqualFold(transitions[index].Name, value) {
+				matches = append(matches, &transitions[index])
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no transition to status or named %q is available. Available transitions:\n%s", value, formatTransitions(transitions))
+	case 1:
+		return matches[0], nil
+	default:
+		candidates := make([]jira.Transition, 0, len(matches))
+		for _, match := range matches {
+			candidates = append(candidates, *match)
+		}
+		return nil, fmt.Errorf("%q is ambiguous; use transition_id with one of:\n%s", value, formatTransitions(candidates))
+	}
+}
+
+// applyTransition moves an issue through a transition, optionally adding a comment and setting screen fields
+func applyTransition(ctx context.Context, client *jira.Client, issueKey string, transitionID string, comment string, fields map[string]interface{}) error {
 	transitionData := map[string]interface{}{
 		"transition": map[string]interface{}{
-			"id": input.TransitionID,
+			"id": transitionID,
 		},
 	}
 
+	if len(fields) > 0 {
+		transitionData["fields"] = fields
+	}
+
 	// Add comment if provided
-	if input.Comment != "" {
+	if comment != "" {
 		transitionData["update"] = map[string]interface{}{
 			"comment": []map[string]interface{}{
 				{
 					"add": map[string]interface{}{
-						"body": input.Comment,
+						"body": comment,
 					},
 				},
 			},
 		}
 	}
 
-	req, err := client.NewRequest("POST", fmt.Sprintf("rest/api/2/issue/%s/transitions", input.IssueKey), transitionData)
+	req, err := client.NewRequestWithContext(ctx, "POST", fmt.Sprintf("rest/api/2/issue/%s/transitions", issueKey), transitionData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transition request: %v", err)
+		return fmt.Errorf("failed to create transition request: %v", err)
 	}
 
-	_, err = client.Do(req, nil)
+	response, err := client.Do(req, nil)
 	if err != nil {
-		return nil, fmt.Errorf("transition failed: %v", err)
+		return fmt.Errorf("transition failed: %v", jira.NewJiraError(response, err))
 	}
+	response.Body.Close()
 
-	return mcp.NewToolResultText("Issue transition completed successfully"), nil
+	return nil
+}
+
+func formatTransitions(transitions []jira.Transition) string {
+	if len(transitions) == 0 {
+		return "(no transitions available)"
+	}
+
+	var sb strings.Builder
+	for _, transition := range transitions {
+		sb.WriteString(fmt.Sprintf("- %s (ID: %s) → %s\n", transition.Name, transition.ID, transition.To.Name))
+	}
+	return strings.TrimRight(sb.String(), "\n")
 }
@@ -0,0 +1,289 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/hdbrzgr/jira-mcp/v2/services"
+	"github.com/hdbrzgr/jira-mcp/v2/util"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Input types for typed tools
+type AddWorklogInput struct {
+	IssueKey       string `json:"issue_key" validate:"required"`
+	TimeSpent      string `json:"time_spent" validate:"required"`
+	Description    string `json:"description,omitempty"`
+	StartedAt      string `json:"started_at,omitempty"`
+	AdjustEstimate string `json:"adjust_estimate,omitempty"`
+	NewEstimate    string `json:"new_estimate,omitempty"`
+	ReduceBy       string `json:"reduce_by,omitempty"`
+}
+
+type GetWorklogsInput struct {
+	IssueKey string `json:"issue_key" validate:"required"`
+}
+
+type UpdateWorklogInput struct {
+	IssueKey       string `json:"issue_key" validate:"required"`
+	WorklogID      string `json:"worklog_id" validate:"required"`
+	TimeSpent      string `json:"time_spent,omitempty"`
+	Description    string `json:"description,omitempty"`
+	StartedAt      string `json:"started_at,omitempty"`
+	AdjustEstimate string `json:"adjust_estimate,omitempty"`
+	NewEstimate    string `json:"new_estimate,omitempty"`
+}
+
+type DeleteWorklogInput struct {
+	IssueKey       string `json:"issue_key" validate:"required"`
+	WorklogID      string `json:"worklog_id" validate:"required"`
+	AdjustEstimate string `json:"adjust_estimate,omitempty"`
+	NewEstimate    string `json:"new_estimate,omitempty"`
+	IncreaseBy     string `json:"increase_by,omitempty"`
+}
+
+func RegisterJiraWorklogTool(s *server.MCPServer) {
+	adjustEstimateDescription := "How to adjust the remaining estimate: 'auto' (default), 'new' (set to new_estimate), 'manual' (change by reduce_by/increase_by), or 'leave'"
+
+	jiraAddWorklogTool := mcp.NewTool("add_worklog",
+		mcp.WithDescription("Log time spent on a Jira issue"),
+		mcp.WithString("issue_key", mcp.Required(), mcp.Description("The unique identifier of the Jira issue (e.g., KP-2, PROJ-123)")),
+		mcp.WithString("time_spent", mcp.Required(), mcp.Description("Time spent in Jira duration format (e.g., '30m', '3h', '1h 30m', '1d')")),
+		mcp.WithString("description", mcp.Description("Description of the work performed (optional)")),
+		mcp.WithString("started_at", mcp.Description("When the work started (e.g., '2024-01-15 09:00' or '2024-01-15T09:00:00+01:00'). Defaults to now")),
+		mcp.WithString("adjust_estimate", mcp.Description(adjustEstimateDescription)),
+		mcp.WithString("new_estimate", mcp.Description("New remaining estimate when adjust_estimate is 'new' (e.g., '2d')")),
+		mcp.WithString("reduce_by", mcp.Description("Amount to reduce the remaining estimate by when adjust_estimate is 'manual' (e.g., '1h')")),
+	)
+	s.AddTool(jiraAddWorklogTool, mcp.NewTypedToolHandler(JiraAddWorklogHandler))
+
+	jiraGetWorklogsTool := mcp.NewTool("get_worklogs",
+		mcp.WithDescription("Retrieve all worklogs of a Jira issue with their IDs, authors, start times, and time spent"),
+		mcp.WithString("issue_key", mcp.Required(), mcp.Description("The unique identifier of the Jira issue (e.g., KP-2, PROJ-123)")),
+	)
+	s.AddTool(jiraGetWorklogsTool, mcp.NewTypedToolHandler(JiraGetWorklogsHandler))
+
+	jiraUpdateWorklogTool := mcp.NewTool("update_worklog",
+		mcp.WithDescription("Modify an existing worklog. Only specified fields will be changed"),
+		mcp.WithString("issue_key", mcp.Required(), mcp.Description("The unique identifier of the Jira issue (e.g., KP-2, PROJ-123)")),
+		mcp.WithString("worklog_id", mcp.Required(), mcp.Description("ID of the worklog to update (from get_worklogs)")),
+		mcp.WithString("time_spent", mcp.Description("New time spent in Jira duration format (e.g., '1h 30m')")),
+		mcp.WithString("description", mcp.Description("New description of the work performed")),
+		mcp.WithString("started_at", mcp.Description("New start time (e.g., '2024-01-15 09:00' or '2024-01-15T09:00:00+01:00')")),
+		mcp.WithString("adjust_estimate", mcp.Description("How to adjust the remaining estimate: 'auto' (default), 'new' (set to new_estimate), or 'leave'")),
+		mcp.WithString("new_estimate", mcp.Description("New remaining estimate when adjust_estimate is 'new' (e.g., '2d')")),
+	)
+	s.AddTool(jiraUpdateWorklogTool, mcp.NewTypedToolHandler(JiraUpdateWorklogHandler))
+
+	jiraDeleteWorklogTool := mcp.NewTool("delete_worklog",
+		mcp.WithDescription("Delete a worklog from a Jira issue"),
+		mcp.WithString("issue_key", mcp.Required(), mcp.Description("The unique identifier of the Jira issue (e.g., KP-2, PROJ-123)")),
+		mcp.WithString("worklog_id", mcp.Required(), mcp.Description("ID of the worklog to delete (from get_worklogs)")),
+		mcp.WithString("adjust_estimate", mcp.Description(adjustEstimateDescription)),
+		mcp.WithString("new_estimate", mcp.Description("New remaining estimate when adjust_estimate is 'new' (e.g., '2d')")),
+		mcp.WithString("increase_by", mcp.Description("Amount to increase the remaining estimate by when adjust_estimate is 'manual' (e.g., '1h')")),
+	)
+	s.AddTool(jiraDeleteWorklogTool, mcp.NewTypedToolHandler(JiraDeleteWorklogHandler))
+}
+
+func JiraAddWorklogHandler(ctx context.Context, request mcp.CallToolRequest, input AddWorklogInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	timeSpent, err := util.NormalizeJiraDuration(input.TimeSpent)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time_spent: %v", err)
+	}
+
+	started := time.Now()
+	if input.StartedAt != "" {
+		if started, err = util.ParseDateTime(input.StartedAt); err != nil {
+			return nil, fmt.Errorf("invalid started_at: %v", err)
+		}
+	}
+
+	query, err := worklogEstimateQuery(input.AdjustEstimate, input.NewEstimate, "reduceBy", input.ReduceBy)
+	if err != nil {
+		return nil, err
+	}
+
+	startedTime := jira.Time(started)
+	worklog := &jira.WorklogRecord{
+		TimeSpent: timeSpent,
+		Comment:   input.Description,
+		Started:   &startedTime,
+	}
+
+	createdWorklog, _, err := client.Issue.AddWorklogRecordWithContext(ctx, input.IssueKey, worklog, withRawQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add worklog: %v", err)
+	}
+
+	return mcp.NewToolResultText("Worklog added successfully!\n" + util.FormatWorklog(createdWorklog)), nil
+}
+
+func JiraGetWorklogsHandler(ctx context.Context, request mcp.CallToolRequest, input GetWorklogsInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	worklogs, err := getIssueWorklogs(ctx, client, input.IssueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(worklogs) == 0 {
+		return mcp.NewToolResultText("No worklogs found for this issue."), nil
+	}
+
+	totalSeconds := 0
+	var sb strings.Builder
+	for _, worklog := range worklogs {
+		totalSeconds += worklog.TimeSpentSeconds
+		sb.WriteString(util.FormatWorklog(&worklog))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("Total: %d worklogs, %s logged\n", len(worklogs), util.FormatDuration(totalSeconds)))
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func JiraUpdateWorklogHandler(ctx context.Context, request mcp.CallToolRequest, input UpdateWorklogInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	worklog := &jira.WorklogRecord{}
+
+	if input.TimeSpent != "" {
+		timeSpent, err := util.NormalizeJiraDuration(input.TimeSpent)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time_spent: %v", err)
+		}
+		worklog.TimeSpent = timeSpent
+	}
+
+	if input.Description != "" {
+		worklog.Comment = input.Description
+	}
+
+	if input.StartedAt != "" {
+		started, err := util.ParseDateTime(input.StartedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid started_at: %v", err)
+		}
+		startedTime := jira.Time(started)
+		worklog.Started = &startedTime
+	}
+
+	if worklog.TimeSpent == "" && worklog.Comment == "" && worklog.Started == nil {
+		return nil, fmt.Errorf("nothing to update: provide at least one of time_spent, description or started_at")
+	}
+
+	query, err := worklogEstimateQuery(input.AdjustEstimate, input.NewEstimate, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	updatedWorklog, _, err := client.Issue.UpdateWorklogRecordWithContext(ctx, input.IssueKey, input.WorklogID, worklog, withRawQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update worklog: %v", err)
+	}
+
+	return mcp.NewToolResultText("Worklog updated successfully!\n" + util.FormatWorklog(updatedWorklog)), nil
+}
+
+func JiraDeleteWorklogHandler(ctx context.Context, request mcp.CallToolRequest, input DeleteWorklogInput) (*mcp.CallToolResult, error) {
+	client := services.JiraClient()
+
+	query, err := worklogEstimateQuery(input.AdjustEstimate, input.NewEstimate, "increaseBy", input.IncreaseBy)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("rest/api/2/issue/%s/worklog/%s", input.IssueKey, input.WorklogID)
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := client.NewRequestWithContext(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	response, err := client.Do(req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete worklog: %v", jira.NewJiraError(response, err))
+	}
+	response.Body.Close()
+
+	return mcp.NewToolResultText(fmt.Sprintf("Worklog %s deleted from %s successfully!", input.WorklogID, input.IssueKey)), nil
+}
+
+// getIssueWorklogs fetches every worklog of an issue, following pagination
+func getIssueWorklogs(ctx context.Context, client *jira.Client, issueKey string) ([]jira.WorklogRecord, error) {
+	var worklogs []jira.WorklogRecord
+
+	for {
+		page, response, err := client.Issue.GetWorklogsWithContext(ctx, issueKey, jira.WithQueryOptions(&jira.GetWorklogsQueryOptions{
+			StartAt: int64(len(worklogs)),
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get worklogs: %v", jira.NewJiraError(response, err))
+		}
+
+		worklogs = append(worklogs, page.Worklogs...)
+		if len(page.Worklogs) == 0 || len(worklogs) >= page.Total {
+			return worklogs, nil
+		}
+	}
+}
+
+// worklogManualArguments maps the manual adjustment query parameters to their tool argument names
+var worklogManualArguments = map[string]string{
+	"reduceBy":   "reduce_by",
+	"increaseBy": "increase_by",
+}
+
+// worklogEstimateQuery builds the adjustEstimate query parameters shared by the worklog endpoints.
+// manualParam is the parameter used by the 'manual' mode (reduceBy or increaseBy), empty when unsupported
+func worklogEstimateQuery(adjustEstimate string, newEstimate string, manualParam string, manualValue string) (url.Values, error) {
+	query := url.Values{}
+
+	switch mode := strings.ToLower(strings.TrimSpace(adjustEstimate)); mode {
+	case "", "auto":
+		return query, nil
+	case "leave":
+		query.Set("adjustEstimate", mode)
+	case "new":
+		estimate, err := util.NormalizeJiraDuration(newEstimate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid new_estimate: %v", err)
+		}
+		query.Set("adjustEstimate", mode)
+		query.Set("newEstimate", estimate)
+	case "manual":
+		if manualParam == "" {
+			return nil, fmt.Errorf("adjust_estimate 'manual' is not supported for this operation")
+		}
+		amount, err := util.NormalizeJiraDuration(manualValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", worklogManualArguments[manualParam], err)
+		}
+		query.Set("adjustEstimate", mode)
+		query.Set(manualParam, amount)
+	default:
+		return nil, fmt.Errorf("invalid adjust_estimate %q: must be auto, new, manual or leave", adjustEstimate)
+	}
+
+	return query, nil
+}
+
+// withRawQuery applies pre-built query parameters to a go-jira request
+func withRawQuery(query url.Values) func(*http.Request) error {
+	return func(req *http.Request) error {
+		req.URL.RawQuery = query.Encode()
+		return nil
+	}
+}
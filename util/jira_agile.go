@@ -0,0 +1,274 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// serverSprintName extracts the sprint name from the string form Jira Server uses for the Sprint field
+var serverSprintName = regexp.MustCompile(`name=([^,\]]*)`)
+
+// Sprint represents a sprint returned by the Jira Agile REST API (rest/agile/1.0)
+// It mirrors jira.Sprint but also carries the sprint goal, which go-jira does not map
+type Sprint struct {
+	ID            int        `json:"id"`
+	Self          string     `json:"self,omitempty"`
+	State         string     `json:"state,omitempty"`
+	Name          string     `json:"name,omitempty"`
+	Goal          string     `json:"goal,omitempty"`
+	StartDate     *time.Time `json:"startDate,omitempty"`
+	EndDate       *time.Time `json:"endDate,omitempty"`
+	CompleteDate  *time.Time `json:"completeDate,omitempty"`
+	OriginBoardID int        `json:"originBoardId,omitempty"`
+}
+
+// FormatSprint converts an agile sprint to a formatted string representation
+func FormatSprint(sprint *Sprint) string {
+	if sprint == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("ID: %d\n", sprint.ID))
+	sb.WriteString(fmt.Sprintf("Name: %s\n", sprint.Name))
+
+	if sprint.State != "" {
+		sb.WriteString(fmt.Sprintf("State: %s\n", sprint.State))
+	}
+
+	if sprint.Goal != "" {
+		sb.WriteString(fmt.Sprintf("Goal: %s\n", sprint.Goal))
+	} else {
+		sb.WriteString("Goal: None\n")
+	}
+
+	if sprint.StartDate != nil && !sprint.StartDate.IsZero() {
+		sb.WriteString(fmt.Sprintf("Start Date: %s\n", sprint.StartDate.Format("2006-01-02 15:04:05")))
+	}
+
+	if sprint.EndDate != nil && !sprint.EndDate.IsZero() {
+		sb.WriteString(fmt.Sprintf("End Date: %s\n", sprint.EndDate.Format("2006-01-02 15:04:05")))
+	}
+
+	if sprint.CompleteDate != nil && !sprint.CompleteDate.IsZero() {
+		sb.WriteString(fmt.Sprintf("Complete Date: %s\n", sprint.CompleteDate.Format("2006-01-02 15:04:05")))
+	}
+
+	// Remaining time is only meaningful while the sprint is running
+	if sprint.State == "active" && sprint.EndDate != nil && !sprint.EndDate.IsZero() {
+		remaining := time.Until(*sprint.EndDate)
+		if remaining > 0 {
+			sb.WriteString(fmt.Sprintf("Days Remaining: %d\n", int(remaining.Hours()/24)))
+		} else {
+			sb.WriteString("Days Remaining: 0 (past end date)\n")
+		}
+	}
+
+	if sprint.OriginBoardID != 0 {
+		sb.WriteString(fmt.Sprintf("Board ID: %d\n", sprint.OriginBoardID))
+	}
+
+	return sb.String()
+}
+
+// SprintNames reads the Sprint field, which Jira Cloud returns as objects and Jira Server as strings
+// such as "com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=1,...,name=KP Sprint 1,...]"
+func SprintNames(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, item := range items {
+		switch sprint := item.(type) {
+		case map[string]interface{}:
+			if name, ok := sprint["name"].(string); ok && name != "" {
+				names = append(names, name)
+			}
+		case string:
+			if match := serverSprintName.FindStringSubmatch(sprint); match != nil {
+				names = append(names, match[1])
+			}
+		}
+	}
+	return names
+}
+
+// Board represents an agile board returned by the Jira Agile REST API
+type Board struct {
+	ID       int            `json:"id"`
+	Self     string         `json:"self,omitempty"`
+	Name     string         `json:"name,omitempty"`
+	Type     string         `json:"type,omitempty"`
+	Location *BoardLocation `json:"location,omitempty"`
+}
+
+// BoardLocation identifies the project or user a board belongs to
+type BoardLocation struct {
+	ProjectID      int    `json:"projectId,omitempty"`
+	ProjectKey     string `json:"projectKey,omitempty"`
+	ProjectName    string `json:"projectName,omitempty"`
+	ProjectTypeKey string `json:"projectTypeKey,omitempty"`
+	DisplayName    string `json:"displayName,omitempty"`
+	Name           string `json:"name,omitempty"`
+}
+
+// BoardConfiguration represents the configuration of an agile board, including the
+// estimation and ranking settings that go-jira's BoardConfiguration does not map
+type BoardConfiguration struct {
+	ID           int                 `json:"id"`
+	Name         string              `json:"name"`
+	Type         string              `json:"type,omitempty"`
+	Filter       BoardFilter         `json:"filter"`
+	SubQuery     *BoardSubQuery      `json:"subQuery,omitempty"`
+	ColumnConfig BoardColumnConfig   `json:"columnConfig"`
+	Estimation   *BoardEstimation    `json:"estimation,omitempty"`
+	Ranking      *BoardRankingConfig `json:"ranking,omitempty"`
+}
+
+// BoardFilter references the saved filter that selects the issues of a board
+type BoardFilter struct {
+	ID string `json:"id"`
+}
+
+// BoardSubQuery is the additional JQL applied by kanban boards
+type BoardSubQuery struct {
+	Query string `json:"query"`
+}
+
+// BoardColumnConfig lists the board columns in display order
+type BoardColumnConfig struct {
+	Columns        []BoardColumn `json:"columns"`
+	ConstraintType string        `json:"constraintType"`
+}
+
+// BoardColumn maps a board column to the workflow statuses shown in it
+type BoardColumn struct {
+	Name     string              `json:"name"`
+	Statuses []BoardColumnStatus `json:"statuses"`
+	Min      int                 `json:"min,omitempty"`
+	Max      int                 `json:"max,omitempty"`
+}
+
+// BoardColumnStatus references a status mapped to a board column
+type BoardColumnStatus struct {
+	ID string `json:"id"`
+}
+
+// BoardEstimation describes how issues on the board are estimated
+type BoardEstimation struct {
+	Type  string                `json:"type"`
+	Field *BoardEstimationField `json:"field,omitempty"`
+}
+
+// BoardEstimationField is the field used for estimation (e.g., Story Points)
+type BoardEstimationField struct {
+	FieldID     string `json:"fieldId"`
+	DisplayName string `json:"displayName"`
+}
+
+// BoardRankingConfig holds the custom field used to rank issues on the board
+type BoardRankingConfig struct {
+	RankCustomFieldID int `json:"rankCustomFieldId"`
+}
+
+// FormatBoard returns a compact single-line representation of an agile board
+func FormatBoard(board *Board) string {
+	if board == nil {
+		return ""
+	}
+
+	parts := []string{
+		fmt.Sprintf("ID: %d", board.ID),
+		fmt.Sprintf("Name: %s", board.Name),
+		fmt.Sprintf("Type: %s", board.Type),
+	}
+
+	if board.Location != nil {
+		if board.Location.ProjectKey != "" {
+			parts = append(parts, fmt.Sprintf("Project: %s (%s)", board.Location.ProjectName, board.Location.ProjectKey))
+		} else if board.Location.DisplayName != "" {
+			parts = append(parts, fmt.Sprintf("Location: %s", board.Location.DisplayName))
+		}
+	}
+
+	return strings.Join(parts, " | ")
+}
+
+// FormatBoardConfiguration converts a board configuration to a formatted string representation
+// statusNames maps status IDs to their display names; unknown IDs are shown as-is
+// filterJQL is the JQL of the board filter and may be empty when it could not be loaded
+func FormatBoardConfiguration(config *BoardConfiguration, statusNames map[string]string, filterJQL string) string {
+	if config == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Board ID: %d\n", config.ID))
+	sb.WriteString(fmt.Sprintf("Name: %s\n", config.Name))
+	if config.Type != "" {
+		sb.WriteString(fmt.Sprintf("Type: %s\n", config.Type))
+	}
+
+	if config.Filter.ID != "" {
+		sb.WriteString(fmt.Sprintf("Filter ID: %s\n", config.Filter.ID))
+	}
+	if filterJQL != "" {
+		sb.WriteString(fmt.Sprintf("Filter JQL: %s\n", filterJQL))
+	}
+	if config.SubQuery != nil && config.SubQuery.Query != "" {
+		sb.WriteString(fmt.Sprintf("Sub-Query: %s\n", config.SubQuery.Query))
+	}
+
+	// Estimation
+	if config.Estimation != nil {
+		estimation := config.Estimation.Type
+		if config.Estimation.Field != nil {
+			estimation = fmt.Sprintf("%s - %s (%s)", estimation, config.Estimation.Field.DisplayName, config.Estimation.Field.FieldID)
+		}
+		sb.WriteString(fmt.Sprintf("Estimation: %s\n", estimation))
+	} else {
+		sb.WriteString("Estimation: None\n")
+	}
+
+	// Ranking
+	if config.Ranking != nil && config.Ranking.RankCustomFieldID != 0 {
+		sb.WriteString(fmt.Sprintf("Ranking Field: customfield_%d\n", config.Ranking.RankCustomFieldID))
+	}
+
+	// Columns
+	if len(config.ColumnConfig.Columns) > 0 {
+		sb.WriteString("Columns:\n")
+		for _, column := range config.ColumnConfig.Columns {
+			var statuses []string
+			for _, status := range column.Statuses {
+				if name, ok := statusNames[status.ID]; ok {
+					statuses = append(statuses, fmt.Sprintf("%s (ID: %s)", name, status.ID))
+				} else {
+					statuses = append(statuses, fmt.Sprintf("ID: %s", status.ID))
+				}
+			}
+
+			sb.WriteString(fmt.Sprintf("- %s", column.Name))
+			if column.Min > 0 || column.Max > 0 {
+				sb.WriteString(fmt.Sprintf(" [min: %d, max: %d]", column.Min, column.Max))
+			}
+			if len(statuses) > 0 {
+				sb.WriteString(fmt.Sprintf(": %s", strings.Join(statuses, ", ")))
+			} else {
+				sb.WriteString(": no statuses mapped")
+			}
+			sb.WriteString("\n")
+		}
+		if config.ColumnConfig.ConstraintType != "" {
+			sb.WriteString(fmt.Sprintf("Column Constraint: %s\n", config.ColumnConfig.ConstraintType))
+		}
+	}
+
+	return sb.String()
+}
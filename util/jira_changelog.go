@@ -0,0 +1,161 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// FieldChange is a single field change extracted from an issue changelog
+type FieldChange struct {
+	At         time.Time
+	Author     string
+	Field      string
+	From       string
+	FromString string
+	To         string
+	ToString   string
+}
+
+// embeddedChangelogLimit is the most histories Jira Cloud embeds in an issue expanded with changelog
+const embeddedChangelogLimit = 100
+
+// changelogPage is a single page of the issue changelog endpoint of Jira Cloud
+type changelogPage struct {
+	StartAt    int                     `json:"startAt"`
+	MaxResults int                     `json:"maxResults"`
+	Total      int                     `json:"total"`
+	IsLast     bool                    `json:"isLast"`
+	Values     []jira.ChangelogHistory `json:"values"`
+}
+
+// LoadFullChangelog replaces an embedded changelog that may have been cut off with every history of the issue.
+// The embedded changelog carries no total, so one that reaches the embedding limit is paged from the changelog
+// endpoint. Jira Server embeds the complete changelog and has no such endpoint, so a 404 keeps it as is
+func LoadFullChangelog(ctx context.Context, client *jira.Client, issue *jira.Issue) error {
+	if issue == nil || issue.Changelog == nil || len(issue.Changelog.Histories) < embeddedChangelogLimit {
+		return nil
+	}
+
+	var histories []jira.ChangelogHistory
+	startAt := 0
+	for {
+		endpoint := fmt.Sprintf("rest/api/2/issue/%s/changelog?startAt=%d&maxResults=%d", issue.Key, startAt, embeddedChangelogLimit)
+		req, err := client.NewRequestWithContext(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %v", err)
+		}
+
+		var page changelogPage
+		response, err := client.Do(req, &page)
+		if err != nil {
+			if response != nil && response.StatusCode == http.StatusNotFound {
+				return nil
+			}
+			return fmt.Errorf("failed to get changelog of %s: %v", issue.Key, jira.NewJiraError(response, err))
+		}
+
+		histories = append(histories, page.Values...)
+		if page.IsLast || len(page.Values) == 0 || len(histories) >= page.Total {
+			break
+		}
+		startAt += len(page.Values)
+	}
+
+	issue.Changelog.Histories = histories
+	return nil
+}
+
+// SkippedHistories counts the changelog histories FieldChanges ignores because their timestamp cannot be parsed
+func SkippedHistories(issue *jira.Issue) int {
+	if issue == nil || issue.Changelog == nil {
+		return 0
+	}
+
+	skipped := 0
+	for _, history := range issue.Changelog.Histories {
+		if _, err := ParseChangelogTime(history.Created); err != nil {
+			skipped++
+		}
+	}
+	return skipped
+}
+
+// ParseChangelogTime parses the timestamp format used by changelog histories
+func ParseChangelogTime(value string) (time.Time, error) {
+	return time.Parse("2006-01-02T15:04:05.000-0700", value)
+}
+
+// FieldChanges returns the changes recorded for a field in the issue changelog, oldest first
+// The field is matched case-insensitively against the changelog field name (e.g., "status", "Sprint")
+// An empty field returns the changes of every field. Histories with unreadable timestamps are skipped;
+// SkippedHistories counts them
+func FieldChanges(issue *jira.Issue, field string) []FieldChange {
+	if issue == nil || issue.Changelog == nil {
+		return nil
+	}
+
+	var changes []FieldChange
+	for _, history := range issue.Changelog.Histories {
+		at, err := ParseChangelogTime(history.Created)
+		if err != nil {
+			continue
+		}
+
+		author := history.Author.DisplayName
+		if author == "" {
+			author = history.Author.Name
+		}
+
+		for _, item := range history.Items {
+			if field != "" && !strings.EqualFold(item.Field, field) {
+				continue
+			}
+			changes = append(changes, FieldChange{
+				At:         at,
+				Author:     author,
+				Field:      item.Field,
+				From:       changelogValue(item.From),
+				FromString: item.FromString,
+				To:         changelogValue(item.To),
+				ToString:   item.ToString,
+			})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].At.Before(changes[j].At)
+	})
+
+	return changes
+}
+
+// FieldValueAt replays field changes to find the display value of a field at a point in time
+// current is the present value, used when the field never changed
+func FieldValueAt(changes []FieldChange, at time.Time, current string) string {
+	if len(changes) == 0 {
+		return current
+	}
+
+	value := changes[0].FromString
+	for _, change := range changes {
+		if change.At.After(at) {
+			break
+		}
+		value = change.ToString
+	}
+
+	return value
+}
+
+func changelogValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
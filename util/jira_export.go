@@ -0,0 +1,224 @@
+package util
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// IssueColumn is a field shown as a column of a table, CSV or JSON export of issues
+type IssueColumn struct {
+	ID   string
+	Name string
+}
+
+// DefaultIssueColumns are exported when no fields are requested
+var DefaultIssueColumns = []string{"summary", "status", "assignee", "priority"}
+
+// NewIssueColumns turns field IDs into columns named after the fields' display names
+// Wildcards and exclusions such as "*all" or "-comment" are not columns and are skipped
+func NewIssueColumns(fieldIDs []string) []IssueColumn {
+	var columns []IssueColumn
+	for _, id := range fieldIDs {
+		if id == "" || id == "key" || strings.HasPrefix(id, "*") || strings.HasPrefix(id, "-") {
+			continue
+		}
+		name := FieldName(id)
+		if name == "" {
+			name = id
+		}
+		columns = append(columns, IssueColumn{ID: id, Name: name})
+	}
+	return columns
+}
+
+// IssueFieldValue renders one field of an issue as plain text
+// System fields are read from the typed struct, custom fields from the issue's unknown fields
+func IssueFieldValue(issue *jira.Issue, fieldID string) string {
+	if fieldID == "key" {
+		return issue.Key
+	}
+	if issue.Fields == nil {
+		return ""
+	}
+
+	fields := issue.Fields
+	switch fieldID {
+	case "summary":
+		return fields.Summary
+	case "description":
+		return fields.Description
+	case "environment":
+		return fields.Environment
+	case "issuetype":
+		return fields.Type.Name
+	case "project":
+		return fields.Project.Key
+	case "status":
+		if fields.Status != nil {
+			return fields.Status.Name
+		}
+	case "priority":
+		if fields.Priority != nil {
+			return fields.Priority.Name
+		}
+	case "resolution":
+		if fields.Resolution != nil {
+			return fields.Resolution.Name
+		}
+	case "assignee":
+		if fields.Assignee != nil {
+			return fields.Assignee.DisplayName
+		}
+	case "reporter":
+		if fields.Reporter != nil {
+			return fields.Reporter.DisplayName
+		}
+	case "creator":
+		if fields.Creator != nil {
+			return fields.Creator.DisplayName
+		}
+	case "parent":
+		if fields.Parent != nil {
+			return fields.Parent.Key
+		}
+	case "labels":
+		return strings.Join(fields.Labels, ", ")
+	case "components":
+		names := make([]string, 0, len(fields.Components))
+		for _, component := range fields.Components {
+			names = append(names, component.Name)
+		}
+		return strings.Join(names, ", ")
+	case "fixVersions":
+		names := make([]string, 0, len(fields.FixVersions))
+		for _, version := range fields.FixVersions {
+			names = append(names, version.Name)
+		}
+		return strings.Join(names, ", ")
+	case "versions":
+		names := make([]string, 0, len(fields.AffectsVersions))
+		for _, version := range fields.AffectsVersions {
+			names = append(names, version.Name)
+		}
+		return strings.Join(names, ", ")
+	case "created":
+		return formatExportTime(time.Time(fields.Created))
+	case "updated":
+		return formatExportTime(time.Time(fields.Updated))
+	case "resolutiondate":
+		return formatExportTime(time.Time(fields.Resolutiondate))
+	case "duedate":
+		if !time.Time(fields.Duedate).IsZero() {
+			return time.Time(fields.Duedate).Format("2006-01-02")
+		}
+	case "timespent":
+		return formatExportDuration(fields.TimeSpent)
+	case "timeestimate":
+		return formatExportDuration(fields.TimeEstimate)
+	case "timeoriginalestimate":
+		return formatExportDuration(fields.TimeOriginalEstimate)
+	default:
+		return FormatFieldValue(fields.Unknowns[fieldID])
+	}
+	return ""
+}
+
+func formatExportTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format("2006-01-02 15:04")
+}
+
+func formatExportDuration(seconds int) string {
+	if seconds <= 0 {
+		return ""
+	}
+	return FormatDuration(seconds)
+}
+
+// tableCell escapes pipes and flattens line breaks so a value fits in one Markdown table cell
+var tableCell = strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ")
+
+// EscapeTableCell makes a value safe to place in a Markdown table cell
+func EscapeTableCell(value string) string {
+	return tableCell.Replace(value)
+}
+
+// FormatIssuesTable renders issues as a Markdown table with the issue key and one column per field
+func FormatIssuesTable(issues []jira.Issue, columns []IssueColumn) string {
+	var sb strings.Builder
+
+	sb.WriteString("| Key |")
+	for _, column := range columns {
+		sb.WriteString(fmt.Sprintf(" %s |", column.Name))
+	}
+	sb.WriteString("\n|---|")
+	sb.WriteString(strings.Repeat("---|", len(columns)))
+	sb.WriteString("\n")
+
+	for index := range issues {
+		sb.WriteString(fmt.Sprintf("| %s |", issues[index].Key))
+		for _, column := range columns {
+			sb.WriteString(fmt.Sprintf(" %s |", EscapeTableCell(IssueFieldValue(&issues[index], column.ID))))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// FormatIssuesCSV renders issues as CSV with a header row of field display names
+func FormatIssuesCSV(issues []jira.Issue, columns []IssueColumn) (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	header := []string{"Key"}
+	for _, column := range columns {
+		header = append(header, column.Name)
+	}
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+
+	for index := range issues {
+		record := []string{issues[index].Key}
+		for _, column := range columns {
+			record = append(record, IssueFieldValue(&issues[index], column.ID))
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return buffer.String(), writer.Error()
+}
+
+// IssueRecords converts issues into JSON-ready records keyed by field ID, each with the issue key
+func IssueRecords(issues []jira.Issue, columns []IssueColumn) []map[string]string {
+	records := make([]map[string]string, 0, len(issues))
+	for index := range issues {
+		record := map[string]string{"key": issues[index].Key}
+		for _, column := range columns {
+			record[column.ID] = IssueFieldValue(&issues[index], column.ID)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// FormatJSON renders a value as indented JSON
+func FormatJSON(value interface{}) (string, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,241 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// fieldRegistry caches the instance's field list so fields can be addressed by display name
+// Custom field IDs such as customfield_10016 differ between Jira instances, display names usually do not
+var fieldRegistry struct {
+	sync.RWMutex
+	fields []jira.Field
+	byID   map[string]*jira.Field
+}
+
+// ErrFieldNotFound is returned by ResolveField when no field matches the given name
+var ErrFieldNotFound = errors.New("field not found")
+
+// defaultCustomFieldTypes are the schema types of the custom fields shown when no fields were requested.
+// Other custom fields, such as Rank or the development summary, are mostly internal bookkeeping
+var defaultCustomFieldTypes = map[string]bool{
+	"com.pyxis.greenhopper.jira:gh-epic-link":        true,
+	"com.pyxis.greenhopper.jira:gh-epic-label":       true,
+	"com.pyxis.greenhopper.jira:gh-sprint":           true,
+	"com.atlassian.jpo:jpo-custom-field-parent":      true,
+	"com.atlassian.teams:rm-teams-custom-field-team": true,
+}
+
+// storyPointsFieldNames are the display names of the Story Points field on Jira Server and Jira Cloud
+var storyPointsFieldNames = []string{"Story Points", "Story point estimate"}
+
+// LoadFields returns every field defined on the Jira instance, fetching the list once and caching it
+func LoadFields(ctx context.Context, client *jira.Client) ([]jira.Field, error) {
+	fieldRegistry.RLock()
+	fields := fieldRegistry.fields
+	fieldRegistry.RUnlock()
+	if fields != nil {
+		return fields, nil
+	}
+
+	fields, _, err := client.Field.GetListWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get field list: %v", err)
+	}
+
+	byID := make(map[string]*jira.Field, len(fields))
+	for index := range fields {
+		byID[fields[index].ID] = &fields[index]
+	}
+
+	fieldRegistry.Lock()
+	fieldRegistry.fields = fields
+	fieldRegistry.byID = byID
+	fieldRegistry.Unlock()
+
+	return fields, nil
+}
+
+// ResolveField finds a field by ID (e.g., "customfield_10016", "duedate"), JQL clause name (e.g., "cf[10016]")
+// or display name (e.g., "Story Points"), ignoring case. A display name shared by several fields is an error
+// that lists their IDs
+func ResolveField(ctx context.Context, client *jira.Client, name string) (*jira.Field, error) {
+	fields, err := LoadFields(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	for index := range fields {
+		if strings.EqualFold(fields[index].ID, name) || strings.EqualFold(fields[index].Key, name) {
+			return &fields[index], nil
+		}
+	}
+
+	var matches []*jira.Field
+	for index := range fields {
+		field := &fields[index]
+		if strings.EqualFold(field.Name, name) {
+			matches = append(matches, field)
+			continue
+		}
+		for _, clause := range field.ClauseNames {
+			if strings.EqualFold(clause, name) {
+				matches = append(matches, field)
+				break
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %q", ErrFieldNotFound, name)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, match := range matches {
+			ids = append(ids, match.ID)
+		}
+		return nil, fmt.Errorf("field name %q is ambiguous; use one of the field IDs: %s", name, strings.Join(ids, ", "))
+	}
+}
+
+// FieldName returns the display name of a field ID from the cached registry
+// It returns an empty string when the field is unknown or the registry has not been loaded yet
+func FieldName(id string) string {
+	fieldRegistry.RLock()
+	defer fieldRegistry.RUnlock()
+
+	if field, ok := fieldRegistry.byID[id]; ok {
+		return field.Name
+	}
+	return ""
+}
+
+// registeredField returns a field by ID from the cached registry, or nil when it is unknown
+func registeredField(id string) *jira.Field {
+	fieldRegistry.RLock()
+	defer fieldRegistry.RUnlock()
+
+	return fieldRegistry.byID[id]
+}
+
+// FormatCustomFields renders the non-empty custom fields of an issue by display name, sorted by name.
+// Only the requested custom field IDs are shown; without any, the fields commonly worth reading are shown:
+// epic, sprint, parent, team and story points
+func FormatCustomFields(unknowns map[string]interface{}, requested []string) string {
+	type customField struct {
+		id    string
+		name  string
+		value string
+	}
+
+	wanted := make(map[string]bool)
+	for _, id := range requested {
+		if strings.HasPrefix(id, "customfield_") {
+			wanted[id] = true
+		}
+	}
+
+	var customFields []customField
+	for id, raw := range unknowns {
+		if !strings.HasPrefix(id, "customfield_") {
+			continue
+		}
+		field := registeredField(id)
+		if len(wanted) > 0 {
+			if !wanted[id] {
+				continue
+			}
+		} else if !defaultCustomField(field) {
+			continue
+		}
+
+		value := FormatFieldValue(raw)
+		if field != nil && field.Schema.Custom == "com.pyxis.greenhopper.jira:gh-sprint" {
+			value = strings.Join(SprintNames(raw), ", ")
+		}
+		if value == "" {
+			continue
+		}
+		name := FieldName(id)
+		if name == "" {
+			name = id
+		}
+		customFields = append(customFields, customField{id: id, name: name, value: value})
+	}
+
+	sort.Slice(customFields, func(i, j int) bool {
+		return customFields[i].name < customFields[j].name
+	})
+
+	var sb strings.Builder
+	for _, field := range customFields {
+		if field.name == field.id {
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", field.id, field.value))
+		} else {
+			sb.WriteString(fmt.Sprintf("- %s (%s): %s\n", field.name, field.id, field.value))
+		}
+	}
+
+	return sb.String()
+}
+
+// defaultCustomField reports whether a custom field is shown when no fields were requested
+func defaultCustomField(field *jira.Field) bool {
+	if field == nil {
+		return false
+	}
+	if defaultCustomFieldTypes[field.Schema.Custom] {
+		return true
+	}
+	for _, name := range storyPointsFieldNames {
+		if strings.EqualFold(field.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatFieldValue renders a raw field value as decoded from JSON
+// Options, users and versions are shown by their value or name; lists are joined with commas
+func FormatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return fmt.Sprint(v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if part := FormatFieldValue(item); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		for _, key := range []string{"value", "displayName", "name", "key"} {
+			if text, ok := v[key].(string); ok && text != "" {
+				if child, ok := v["child"].(map[string]interface{}); ok {
+					return text + " / " + FormatFieldValue(child)
+				}
+				return text
+			}
+		}
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -12,6 +12,12 @@ import (
 // FormatJiraIssue converts a Jira issue struct to a formatted string representation
 // It handles the andygrunwald/go-jira Issue structure
 func FormatJiraIssue(issue *jira.Issue) string {
+	return FormatJiraIssueWithFields(issue, nil)
+}
+
+// FormatJiraIssueWithFields formats an issue like FormatJiraIssue, limiting the custom fields shown
+// to the requested field IDs
+func FormatJiraIssueWithFields(issue *jira.Issue, requested []string) string {
 	var sb strings.Builder
 
 	// Basic issue information
@@ -151,6 +157,12 @@ func FormatJiraIssue(issue *jira.Issue) string {
 			}
 		}
 
+		// Custom fields, shown by display name once the field registry is loaded
+		if customFields := FormatCustomFields(fields.Unknowns, requested); customFields != "" {
+			sb.WriteString("Custom Fields:\n")
+			sb.WriteString(customFields)
+		}
+
 		// Subtasks
 		if len(fields.Subtasks) > 0 {
 			sb.WriteString("Subtasks:\n")
@@ -235,20 +247,63 @@ func FormatJiraIssueCompact(issue *jira.Issue) string {
 	return strings.Join(parts, " | ")
 }
 
+// FormatWorklog converts a Jira worklog record to a formatted string representation
+func FormatWorklog(worklog *jira.WorklogRecord) string {
+	if worklog == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("ID: %s\n", worklog.ID))
+
+	if worklog.Author != nil {
+		sb.WriteString(fmt.Sprintf("Author: %s", worklog.Author.DisplayName))
+		if worklog.Author.EmailAddress != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", worklog.Author.EmailAddress))
+		}
+		sb.WriteString("\n")
+	}
+
+	if worklog.Started != nil && !time.Time(*worklog.Started).IsZero() {
+		sb.WriteString(fmt.Sprintf("Started: %s\n", time.Time(*worklog.Started).Format("2006-01-02 15:04:05")))
+	}
+
+	sb.WriteString(fmt.Sprintf("Time Spent: %s", worklog.TimeSpent))
+	if worklog.TimeSpentSeconds > 0 {
+		sb.WriteString(fmt.Sprintf(" (%d seconds)", worklog.TimeSpentSeconds))
+	}
+	sb.WriteString("\n")
+
+	if worklog.Comment != "" {
+		sb.WriteString(fmt.Sprintf("Comment: %s\n", worklog.Comment))
+	}
+
+	if worklog.Updated != nil && worklog.Created != nil && !time.Time(*worklog.Updated).Equal(time.Time(*worklog.Created)) {
+		sb.WriteString(fmt.Sprintf("Updated: %s", time.Time(*worklog.Updated).Format("2006-01-02 15:04:05")))
+		if worklog.UpdateAuthor != nil {
+			sb.WriteString(fmt.Sprintf(" by %s", worklog.UpdateAuthor.DisplayName))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
 // EpicLinkFieldID represents the discovered Epic Link field ID
 var EpicLinkFieldID string
 
 // DiscoverEpicLinkFieldID discovers the Epic Link custom field ID for classic projects
-// Returns the field ID (e.g., "customfield_10014") or empty string if not found
+// Returns the field ID (e.g., "customfield_10014"), or an error wrapping ErrFieldNotFound if the instance has no Epic Link field
 func DiscoverEpicLinkFieldID(ctx context.Context, client *jira.Client) (string, error) {
 	// Return cached value if already discovered
 	if EpicLinkFieldID != "" {
 		return EpicLinkFieldID, nil
 	}
 
-	fields, _, err := client.Field.GetListWithContext(ctx)
+	fields, err := LoadFields(ctx, client)
 	if err != nil {
-		return "", fmt.Errorf("failed to get field list: %v", err)
+		return "", err
 	}
 
 	// Look for Epic Link field
@@ -262,5 +317,86 @@ func DiscoverEpicLinkFieldID(ctx context.Context, client *jira.Client) (string,
 		}
 	}
 
-	return "", fmt.Errorf("epic link field not found - this may be a next-gen project or the field is not configured")
+	return "", fmt.Errorf("%w: epic link - this may be a next-gen project or the field is not configured", ErrFieldNotFound)
+}
+
+// EpicNameFieldID represents the discovered Epic Name field ID
+var EpicNameFieldID string
+
+// DiscoverEpicNameFieldID discovers the Epic Name custom field ID for classic projects
+// Returns the field ID (e.g., "customfield_10011"), or an error wrapping ErrFieldNotFound if the instance has no Epic Name field
+func DiscoverEpicNameFieldID(ctx context.Context, client *jira.Client) (string, error) {
+	// Return cached value if already discovered
+	if EpicNameFieldID != "" {
+		return EpicNameFieldID, nil
+	}
+
+	fields, err := LoadFields(ctx, client)
+	if err != nil {
+		return "", err
+	}
+
+	// The field name is localized, so identify it by its schema type
+	for _, field := range fields {
+		if field.Custom && field.Schema.Custom == "com.pyxis.greenhopper.jira:gh-epic-label" {
+			EpicNameFieldID = field.ID
+			return EpicNameFieldID, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: epic name - this may be a next-gen project or the field is not configured", ErrFieldNotFound)
+}
+
+// StoryPointsField represents the discovered Story Points field
+var StoryPointsField *jira.Field
+
+// DiscoverStoryPointsField discovers the Story Points custom field
+// Company-managed projects use "Story Points", team-managed projects use "Story point estimate"
+func DiscoverStoryPointsField(ctx context.Context, client *jira.Client) (*jira.Field, error) {
+	// Return cached value if already discovered
+	if StoryPointsField != nil {
+		return StoryPointsField, nil
+	}
+
+	fields, err := LoadFields(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range storyPointsFieldNames {
+		for index := range fields {
+			if fields[index].Custom && strings.EqualFold(fields[index].Name, name) {
+				StoryPointsField = &fields[index]
+				return StoryPointsField, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("story points field not found - estimation may not be configured on this instance")
+}
+
+// SprintFieldID represents the discovered Sprint field ID
+var SprintFieldID string
+
+// DiscoverSprintFieldID discovers the Sprint custom field ID of Jira Software
+// Returns the field ID (e.g., "customfield_10020") or an error if the instance has no Sprint field
+func DiscoverSprintFieldID(ctx context.Context, client *jira.Client) (string, error) {
+	// Return cached value if already discovered
+	if SprintFieldID != "" {
+		return SprintFieldID, nil
+	}
+
+	fields, err := LoadFields(ctx, client)
+	if err != nil {
+		return "", err
+	}
+
+	for _, field := range fields {
+		if field.Custom && field.Schema.Custom == "com.pyxis.greenhopper.jira:gh-sprint" {
+			SprintFieldID = field.ID
+			return SprintFieldID, nil
+		}
+	}
+
+	return "", fmt.Errorf("sprint field not found - Jira Software may not be installed on this instance")
 }
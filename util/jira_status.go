@@ -0,0 +1,83 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// IssueTypeStatuses represents the statuses an issue type can take in a project
+// as returned by rest/api/2/project/{key}/statuses
+type IssueTypeStatuses struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Subtask  bool          `json:"subtask"`
+	Statuses []jira.Status `json:"statuses"`
+}
+
+// statusCategoryOrder lists status category keys in workflow order
+var statusCategoryOrder = []string{"new", "indeterminate", "done"}
+
+// FormatProjectStatuses converts the statuses of a project's issue types to a formatted string
+// Statuses are summarized by status category first, then listed per issue type
+func FormatProjectStatuses(projectKey string, issueTypes []IssueTypeStatuses) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Statuses for project %s\n", projectKey))
+
+	// Collect the distinct status names per category across all issue types
+	categoryNames := make(map[string]string)
+	byCategory := make(map[string][]string)
+	seen := make(map[string]bool)
+	var extraCategories []string
+	for _, issueType := range issueTypes {
+		for _, status := range issueType.Statuses {
+			if seen[status.Name] {
+				continue
+			}
+			seen[status.Name] = true
+
+			key := status.StatusCategory.Key
+			if _, ok := categoryNames[key]; !ok {
+				categoryNames[key] = status.StatusCategory.Name
+				if !containsString(statusCategoryOrder, key) {
+					extraCategories = append(extraCategories, key)
+				}
+			}
+			byCategory[key] = append(byCategory[key], status.Name)
+		}
+	}
+
+	sb.WriteString("\nBy Status Category:\n")
+	for _, key := range append(append([]string{}, statusCategoryOrder...), extraCategories...) {
+		names, ok := byCategory[key]
+		if !ok {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("- %s (statusCategory key: %s): %s\n", categoryNames[key], key, strings.Join(names, ", ")))
+	}
+
+	sb.WriteString("\nBy Issue Type:\n")
+	for _, issueType := range issueTypes {
+		sb.WriteString(fmt.Sprintf("\n%s (ID: %s)", issueType.Name, issueType.ID))
+		if issueType.Subtask {
+			sb.WriteString(" [Subtask]")
+		}
+		sb.WriteString(":\n")
+		for _, status := range issueType.Statuses {
+			sb.WriteString(fmt.Sprintf("- %s (ID: %s) [%s]\n", status.Name, status.ID, status.StatusCategory.Name))
+		}
+	}
+
+	return sb.String()
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
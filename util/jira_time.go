@@ -0,0 +1,101 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateTimeLayouts lists the timestamp formats accepted from tool arguments, most specific first
+var dateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseDateTime parses a user supplied date or timestamp
+// Values without a UTC offset are interpreted in the server's local time zone
+func ParseDateTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q: expected a format like 2006-01-02, 2006-01-02 15:04 or 2006-01-02T15:04:05-07:00", value)
+}
+
+// durationPattern matches Jira duration strings such as "1h 30m", "2d" or "1w 2d 4h"
+var durationPattern = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?\s*[wdhm]\s*)+$`)
+
+// durationPartPattern extracts the individual amount/unit pairs of a duration
+var durationPartPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([wdhm])`)
+
+// ParseJiraDuration parses a Jira duration string like "1h 30m" into seconds
+// Days and weeks use Jira's defaults of 8 hours per day and 5 days per week
+func ParseJiraDuration(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !durationPattern.MatchString(value) {
+		return 0, fmt.Errorf("invalid duration %q: expected a Jira duration like 30m, 1h 30m, 2d or 1w", value)
+	}
+
+	unitSeconds := map[string]float64{
+		"m": 60,
+		"h": 60 * 60,
+		"d": 8 * 60 * 60,
+		"w": 5 * 8 * 60 * 60,
+	}
+
+	var seconds float64
+	for _, match := range durationPartPattern.FindAllStringSubmatch(value, -1) {
+		amount, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %v", value, err)
+		}
+		seconds += amount * unitSeconds[match[2]]
+	}
+
+	if seconds < 60 {
+		return 0, fmt.Errorf("invalid duration %q: must be at least 1m", value)
+	}
+
+	return int(seconds), nil
+}
+
+// NormalizeJiraDuration validates a duration string and returns it in Jira's canonical "1h 30m" spacing
+func NormalizeJiraDuration(value string) (string, error) {
+	if _, err := ParseJiraDuration(value); err != nil {
+		return "", err
+	}
+
+	var parts []string
+	for _, match := range durationPartPattern.FindAllStringSubmatch(strings.ToLower(value), -1) {
+		parts = append(parts, match[1]+match[2])
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// FormatDuration renders seconds as hours and minutes (e.g., "12h 30m")
+// Days are avoided on purpose since their length depends on the instance configuration
+func FormatDuration(seconds int) string {
+	hours := seconds / 3600
+	minutes := (seconds % 3600) / 60
+
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}